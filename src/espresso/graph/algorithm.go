@@ -0,0 +1,223 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	ErrNotDirected   = errors.New("graph is not directed")
+	ErrNotUndirected = errors.New("graph is not undirected")
+)
+
+func (g *Graph) Vertices() []*Vertex {
+	vertices := make([]*Vertex, 0, len(g.vertices))
+	for _, v := range g.vertices {
+		vertices = append(vertices, v)
+	}
+	sortVertices(vertices)
+	return vertices
+}
+
+func sortVertices(vertices []*Vertex) {
+	sort.Slice(vertices, func(i, j int) bool { return vertices[i].id < vertices[j].id })
+}
+
+func (v *Vertex) outEdges() []*Edge {
+	if v.edges == nil {
+		return nil
+	}
+	edges := make([]*Edge, 0, v.edges.Len())
+	for i := v.edges.Front(); i != nil; i = i.Next() {
+		e := i.Value.(*Edge)
+		if _, ok := e.link[v.id]; ok {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+func (v *Vertex) inEdges() []*Edge {
+	if v.edges == nil {
+		return nil
+	}
+	edges := make([]*Edge, 0, v.edges.Len())
+	for i := v.edges.Front(); i != nil; i = i.Next() {
+		e := i.Value.(*Edge)
+		for _, adj := range e.link {
+			if adj == v {
+				edges = append(edges, e)
+				break
+			}
+		}
+	}
+	return edges
+}
+
+func (e *Edge) adjacent(v *Vertex) *Vertex {
+	return e.link[v.id]
+}
+
+func (e *Edge) Ends() (*Vertex, *Vertex) {
+	for k, v := range e.link {
+		u := e.vertex(k)
+		if !e.directed && v.id < u.id {
+			return v, u
+		}
+		return u, v
+	}
+	return nil, nil
+}
+
+func (e *Edge) tail(head *Vertex) *Vertex {
+	for k, v := range e.link {
+		if v == head {
+			return e.vertex(k)
+		}
+	}
+	return nil
+}
+
+// Vertex at the start of the link keyed by id, resolved without the graph.
+func (e *Edge) vertex(id string) *Vertex {
+	if e.from.id == id {
+		return e.from
+	}
+	return e.link[e.from.id]
+}
+
+func (g *Graph) AllEdges() []*Edge {
+	edges := make([]*Edge, 0, g.edges)
+	seen := make(map[*Edge]bool)
+	for _, v := range g.Vertices() {
+		for _, e := range v.outEdges() {
+			if !seen[e] {
+				seen[e] = true
+				edges = append(edges, e)
+			}
+		}
+	}
+	return edges
+}
+
+// Hyperedges are not part of AllEdges; listed in the order of their first vertex.
+func (g *Graph) HyperEdges() []*Edge {
+	edges := make([]*Edge, 0)
+	seen := make(map[*Edge]bool)
+	for _, v := range g.Vertices() {
+		if v.edges == nil {
+			continue
+		}
+		for i := v.edges.Front(); i != nil; i = i.Next() {
+			if e := i.Value.(*Edge); e.members != nil && !seen[e] {
+				seen[e] = true
+				edges = append(edges, e)
+			}
+		}
+	}
+	return edges
+}
+
+func edgeWeights(g *Graph, key string) (map[*Edge]float64, error) {
+	weights := make(map[*Edge]float64)
+	for _, e := range g.AllEdges() {
+		w := 1.0
+		if key != "" {
+			if _, ok := e.Get(key); ok {
+				var err error
+				if w, err = e.GetFloat(key); err != nil {
+					return nil, err
+				}
+			}
+		}
+		weights[e] = w
+	}
+	return weights, nil
+}
+
+func (g *Graph) copyVertex(v *Vertex) *Vertex {
+	c := g.Vertex(v.id).Label(v.label)
+	c.SetMap(v.data.values)
+	return c
+}
+
+func (g *Graph) copyEdge(e *Edge, from, to *Vertex) *Edge {
+	c := g.Edge(from.id, to.id).Label(e.label)
+	g.setEdgeId(c, e.id)
+	c.SetMap(e.data.values)
+	return c
+}
+
+// New edge with the same id, vertex ids and direction; the edge may come from
+// another graph or have been removed.
+func (g *Graph) copyKind(e *Edge) *Edge {
+	var c *Edge
+	if e.members != nil {
+		c = &Edge{graph: g, members: make([]*Vertex, len(e.members))}
+		for i, v := range e.members {
+			c.members[i] = g.Vertex(v.id)
+		}
+	}
+	for k, to := range e.link {
+		c = g.edge(g.Vertex(k), g.Vertex(to.id), e.directed)
+		break
+	}
+	if c == nil {
+		return nil
+	}
+	c.id = e.id
+	return g.add(c)
+}
+
+func (g *Graph) emptyCopy() *Graph {
+	c := &Graph{_type: g._type}
+	c.SetMap(g.data.values)
+	for _, v := range g.vertices {
+		c.copyVertex(v)
+	}
+	return c
+}
+
+// Subgraph induced by the vertices in keep, as a new graph with the labels,
+// properties and edge ids of g.
+func (g *Graph) induced(keep map[*Vertex]bool) *Graph {
+	c := &Graph{_type: g._type}
+	c.SetMap(g.data.values)
+	for _, v := range g.Vertices() {
+		if keep[v] {
+			c.copyVertex(v)
+		}
+	}
+	for _, e := range append(g.AllEdges(), g.HyperEdges()...) {
+		inside := true
+		for _, v := range e.Members() {
+			inside = inside && keep[v]
+		}
+		if inside {
+			c.copyKind(e).Label(e.label).SetMap(e.data.values)
+		}
+	}
+	return c
+}
+
+// Distinct adjacent vertices in either direction, self excluded.
+func (v *Vertex) neighbors() []*Vertex {
+	if v.edges == nil {
+		return nil
+	}
+	seen := map[*Vertex]bool{v: true}
+	out := make([]*Vertex, 0, v.edges.Len())
+	for i := v.edges.Front(); i != nil; i = i.Next() {
+		e := i.Value.(*Edge)
+		for k, adj := range e.link {
+			for _, u := range []*Vertex{e.vertex(k), adj} {
+				if !seen[u] {
+					seen[u] = true
+					out = append(out, u)
+				}
+			}
+		}
+	}
+	sortVertices(out)
+	return out
+}
@@ -0,0 +1,125 @@
+package graph
+
+type Component struct {
+	Vertices []*Vertex
+	Edges    []*Edge
+}
+
+type Biconnected struct {
+	ArticulationPoints []*Vertex
+	Bridges            []*Edge
+	Components         []Component
+}
+
+type biconnected struct {
+	disc, low map[*Vertex]int
+	time      int
+	stack     []*Edge
+	points    map[*Vertex]bool
+	result    *Biconnected
+}
+
+// Hopcroft-Tarjan depth-first search, one pass for points, bridges and components.
+func BiconnectedComponents(g *Graph) (*Biconnected, error) {
+	if g.Type() != UNDIRECTED {
+		return nil, ErrNotUndirected
+	}
+	b := &biconnected{
+		disc:   make(map[*Vertex]int),
+		low:    make(map[*Vertex]int),
+		points: make(map[*Vertex]bool),
+		result: &Biconnected{},
+	}
+	for _, v := range g.Vertices() {
+		if _, ok := b.disc[v]; !ok {
+			b.visit(v, nil)
+		}
+	}
+	for v := range b.points {
+		b.result.ArticulationPoints = append(b.result.ArticulationPoints, v)
+	}
+	sortVertices(b.result.ArticulationPoints)
+	return b.result, nil
+}
+
+func ArticulationPoints(g *Graph) ([]*Vertex, error) {
+	b, err := BiconnectedComponents(g)
+	if err != nil {
+		return nil, err
+	}
+	return b.ArticulationPoints, nil
+}
+
+func Bridges(g *Graph) ([]*Edge, error) {
+	b, err := BiconnectedComponents(g)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bridges, nil
+}
+
+func (b *biconnected) visit(v *Vertex, parent *Edge) {
+	b.time++
+	b.disc[v] = b.time
+	b.low[v] = b.time
+	children := 0
+
+	for _, e := range v.outEdges() {
+		if e == parent {
+			continue
+		}
+		u := e.adjacent(v)
+		if u == v {
+			continue
+		}
+		if _, seen := b.disc[u]; !seen {
+			children++
+			b.stack = append(b.stack, e)
+			b.visit(u, e)
+			if b.low[u] < b.low[v] {
+				b.low[v] = b.low[u]
+			}
+			if b.low[u] > b.disc[v] {
+				b.result.Bridges = append(b.result.Bridges, e)
+			}
+			if b.low[u] >= b.disc[v] {
+				if parent != nil {
+					b.points[v] = true
+				}
+				b.pop(e)
+			}
+		} else if b.disc[u] < b.disc[v] {
+			b.stack = append(b.stack, e)
+			if b.disc[u] < b.low[v] {
+				b.low[v] = b.disc[u]
+			}
+		}
+	}
+
+	if parent == nil && children > 1 {
+		b.points[v] = true
+	}
+}
+
+func (b *biconnected) pop(last *Edge) {
+	var c Component
+	seen := make(map[*Vertex]bool)
+	for {
+		e := b.stack[len(b.stack)-1]
+		b.stack = b.stack[:len(b.stack)-1]
+		c.Edges = append(c.Edges, e)
+		for k, v := range e.link {
+			for _, w := range []*Vertex{e.vertex(k), v} {
+				if !seen[w] {
+					seen[w] = true
+					c.Vertices = append(c.Vertices, w)
+				}
+			}
+		}
+		if e == last {
+			break
+		}
+	}
+	sortVertices(c.Vertices)
+	b.result.Components = append(b.result.Components, c)
+}
@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"testing"
+)
+
+func vertexIds(vertices []*Vertex) []string {
+	ids := make([]string, len(vertices))
+	for i, v := range vertices {
+		ids[i] = v.id
+	}
+	return ids
+}
+
+func TestBiconnectedComponents(t *testing.T) {
+	g := NewUndirected()
+	g.Edge("1", "2")
+	g.Edge("2", "3")
+	g.Edge("3", "1")
+	e34 := g.Edge("3", "4")
+	g.Edge("4", "5")
+	g.Edge("5", "6")
+	g.Edge("6", "4")
+	g.Edge("6", "7")
+	g.Edge("7", "6")
+	e78 := g.Edge("7", "8")
+	g.Edge("8", "8")
+	g.Vertex("9")
+
+	b, err := BiconnectedComponents(g)
+	if err != nil {
+		t.Fatalf("Error biconnected components: %v", err)
+	}
+
+	if ids := vertexIds(b.ArticulationPoints); len(ids) != 4 ||
+		ids[0] != "3" || ids[1] != "4" || ids[2] != "6" || ids[3] != "7" {
+		t.Errorf("Error articulation points (3,4,6,7): %v", ids)
+	}
+
+	if len(b.Bridges) != 2 {
+		t.Fatalf("Error bridges (2): %d", len(b.Bridges))
+	}
+	found := map[*Edge]bool{b.Bridges[0]: true, b.Bridges[1]: true}
+	if !found[e34] || !found[e78] {
+		t.Errorf("Error bridges should be 3-4 and 7-8: %v", b.Bridges)
+	}
+
+	if n := len(b.Components); n != 5 {
+		t.Errorf("Error components (5): %d", n)
+	}
+	for _, c := range b.Components {
+		if len(c.Vertices) == 2 && c.Vertices[0].id == "6" && len(c.Edges) != 2 {
+			t.Errorf("Error parallel edges 6-7 should be one component: %d", len(c.Edges))
+		}
+	}
+
+	if _, err := ArticulationPoints(NewDirected()); err != ErrNotUndirected {
+		t.Errorf("Error directed graph should be rejected: %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package graph
+
+import (
+	"errors"
+	"math"
+)
+
+var ErrNotBipartite = errors.New("graph is not bipartite")
+
+// Two-coloring by breadth-first search, edge direction is ignored.
+func IsBipartite(g *Graph) ([]*Vertex, []*Vertex, bool) {
+	side := make(map[*Vertex]int, len(g.vertices))
+	left, right := make([]*Vertex, 0), make([]*Vertex, 0)
+	for _, root := range g.Vertices() {
+		if _, ok := side[root]; ok {
+			continue
+		}
+		side[root] = 0
+		queue := []*Vertex{root}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			if side[v] == 0 {
+				left = append(left, v)
+			} else {
+				right = append(right, v)
+			}
+			for _, e := range v.outEdges() {
+				if e.adjacent(v) == v {
+					return nil, nil, false
+				}
+			}
+			for _, u := range v.neighbors() {
+				s, ok := side[u]
+				if !ok {
+					side[u] = 1 - side[v]
+					queue = append(queue, u)
+				} else if s == side[v] {
+					return nil, nil, false
+				}
+			}
+		}
+	}
+	sortVertices(left)
+	sortVertices(right)
+	return left, right, true
+}
+
+// Pairs maps every matched vertex id to the id of its mate, in both directions.
+type Matching struct {
+	Pairs  map[string]string
+	Edges  []*Edge
+	Weight float64
+}
+
+func (m *Matching) Size() int {
+	return len(m.Edges)
+}
+
+func (m *Matching) add(e *Edge, a, b *Vertex) {
+	m.Pairs[a.id] = b.id
+	m.Pairs[b.id] = a.id
+	m.Edges = append(m.Edges, e)
+}
+
+// Edges between two vertices in either direction, self-loops excluded.
+func edgesBetween(a, b *Vertex) []*Edge {
+	out := make([]*Edge, 0)
+	for _, e := range a.outEdges() {
+		if e.adjacent(a) == b && a != b {
+			out = append(out, e)
+		}
+	}
+	if a.graph.Type() != UNDIRECTED {
+		for _, e := range b.outEdges() {
+			if e.directed && e.adjacent(b) == a && a != b {
+				out = append(out, e)
+			}
+		}
+	}
+	return out
+}
+
+func HopcroftKarp(g *Graph) (*Matching, error) {
+	left, right, ok := IsBipartite(g)
+	if !ok {
+		return nil, ErrNotBipartite
+	}
+	adjacent := make(map[*Vertex][]*Vertex, len(left))
+	for _, v := range left {
+		adjacent[v] = v.neighbors()
+	}
+
+	mate := make(map[*Vertex]*Vertex, len(left)+len(right))
+	dist := make(map[*Vertex]int, len(left))
+	const inf = math.MaxInt32
+
+	bfs := func() bool {
+		queue := make([]*Vertex, 0, len(left))
+		for _, v := range left {
+			if mate[v] == nil {
+				dist[v] = 0
+				queue = append(queue, v)
+			} else {
+				dist[v] = inf
+			}
+		}
+		found := false
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, u := range adjacent[v] {
+				w := mate[u]
+				if w == nil {
+					found = true
+				} else if dist[w] == inf {
+					dist[w] = dist[v] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+		return found
+	}
+
+	var dfs func(v *Vertex) bool
+	dfs = func(v *Vertex) bool {
+		for _, u := range adjacent[v] {
+			w := mate[u]
+			if w == nil || (dist[w] == dist[v]+1 && dfs(w)) {
+				mate[v] = u
+				mate[u] = v
+				return true
+			}
+		}
+		dist[v] = inf
+		return false
+	}
+
+	for bfs() {
+		for _, v := range left {
+			if mate[v] == nil {
+				dfs(v)
+			}
+		}
+	}
+
+	m := &Matching{Pairs: make(map[string]string)}
+	for _, v := range left {
+		if u := mate[v]; u != nil {
+			e := edgesBetween(v, u)[0]
+			m.add(e, v, u)
+		}
+	}
+	return m, nil
+}
+
+// Hungarian algorithm over the bipartition; pairs without an edge are never assigned.
+// Maximum cardinality is preferred, then minimum (or maximum) total weight.
+func Assignment(g *Graph, weight string, maximize bool) (*Matching, error) {
+	left, right, ok := IsBipartite(g)
+	if !ok {
+		return nil, ErrNotBipartite
+	}
+	weights, err := edgeWeights(g, weight)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(left)
+	if len(right) > n {
+		n = len(right)
+	}
+	best := make([][]*Edge, n)
+	cost := make([][]float64, n)
+	big := 1.0
+	for _, w := range weights {
+		big += math.Abs(w)
+	}
+	big *= float64(n + 1)
+	for i := range cost {
+		best[i] = make([]*Edge, n)
+		cost[i] = make([]float64, n)
+		for j := range cost[i] {
+			cost[i][j] = big
+			if i >= len(left) || j >= len(right) {
+				continue
+			}
+			for _, e := range edgesBetween(left[i], right[j]) {
+				w := weights[e]
+				if maximize {
+					w = -w
+				}
+				if best[i][j] == nil || w < cost[i][j] {
+					best[i][j] = e
+					cost[i][j] = w
+				}
+			}
+		}
+	}
+
+	m := &Matching{Pairs: make(map[string]string)}
+	for i, j := range hungarian(cost) {
+		if e := best[i][j]; e != nil {
+			m.add(e, left[i], right[j])
+			m.Weight += weights[e]
+		}
+	}
+	return m, nil
+}
+
+// Square cost matrix, returns the column assigned to each row.
+func hungarian(cost [][]float64) []int {
+	n := len(cost)
+	u := make([]float64, n+1)
+	v := make([]float64, n+1)
+	p := make([]int, n+1)
+	way := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, n+1)
+		used := make([]bool, n+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		for p[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := cost[i0-1][j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+	rows := make([]int, n)
+	for j := 1; j <= n; j++ {
+		if p[j] != 0 {
+			rows[p[j]-1] = j - 1
+		}
+	}
+	return rows
+}
@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestIsBipartite(t *testing.T) {
+	g := New()
+	for _, actor := range []string{"Keanu", "Laurence", "Carrie-Anne"} {
+		g.Vertex(actor).Label("Actor")
+		for _, movie := range []string{"Matrix", "Reloaded", "Revolutions"} {
+			g.Edge(actor, movie).Label("ACTS_IN")
+		}
+	}
+
+	left, right, ok := IsBipartite(g)
+	if !ok {
+		t.Fatalf("Error actor/movie graph should be bipartite")
+	}
+	if len(left) != 3 || len(right) != 3 || left[0].label != "Actor" {
+		t.Errorf("Error partitions: %v, %v", vertexIds(left), vertexIds(right))
+	}
+
+	g.Edge("Matrix", "Reloaded").Label("SEQUEL")
+	if _, _, ok := IsBipartite(g); ok {
+		t.Errorf("Error odd cycle should not be bipartite")
+	}
+	if _, err := HopcroftKarp(g); err != ErrNotBipartite {
+		t.Errorf("Error matching of non bipartite graph: %v", err)
+	}
+}
+
+func TestHopcroftKarp(t *testing.T) {
+	g := NewUndirected()
+	g.Edge("w1", "j1")
+	g.Edge("w1", "j2")
+	g.Edge("w2", "j1")
+	g.Edge("w3", "j1")
+	g.Edge("w3", "j3")
+	g.Edge("w4", "j3")
+	g.Edge("w4", "j3")
+
+	m, err := HopcroftKarp(g)
+	if err != nil {
+		t.Fatalf("Error matching: %v", err)
+	}
+	if m.Size() != 3 {
+		t.Errorf("Error maximum matching size (3): %d %v", m.Size(), m.Pairs)
+	}
+	if m.Pairs["w1"] != "j2" || m.Pairs["j2"] != "w1" || m.Pairs["j1"] != "w2" && m.Pairs["j1"] != "w3" {
+		t.Errorf("Error matching pairs: %v", m.Pairs)
+	}
+}
+
+func TestAssignment(t *testing.T) {
+	g := NewUndirected()
+	costs := map[[2]string]int{
+		{"a", "x"}: 4, {"a", "y"}: 1, {"a", "z"}: 3,
+		{"b", "x"}: 2, {"b", "y"}: 0, {"b", "z"}: 5,
+		{"c", "x"}: 3, {"c", "y"}: 2, {"c", "z"}: 2,
+	}
+	for pair, c := range costs {
+		g.Edge(pair[0], pair[1]).Set("cost", c)
+	}
+	g.Vertex("d")
+	g.Edge("d", "w").Set("cost", 9)
+
+	m, err := Assignment(g, "cost", false)
+	if err != nil {
+		t.Fatalf("Error assignment: %v", err)
+	}
+	if m.Size() != 4 || m.Weight != 14 {
+		t.Errorf("Error minimum assignment (4, 14): %d, %g %v", m.Size(), m.Weight, m.Pairs)
+	}
+	if m.Pairs["a"] != "y" || m.Pairs["b"] != "x" || m.Pairs["c"] != "z" {
+		t.Errorf("Error minimum assignment pairs: %v", m.Pairs)
+	}
+
+	m, _ = Assignment(g, "cost", true)
+	if m.Weight != 20 {
+		t.Errorf("Error maximum assignment (20): %g %v", m.Weight, m.Pairs)
+	}
+
+	// fewer edges than rows: only real edges are assigned
+	h := NewUndirected()
+	h.Edge("a", "x").Set("cost", 1)
+	h.Edge("b", "x").Set("cost", 5)
+	m, _ = Assignment(h, "cost", false)
+	if m.Size() != 1 || m.Pairs["x"] != "a" {
+		t.Errorf("Error partial assignment: %v", m.Pairs)
+	}
+}
@@ -0,0 +1,111 @@
+package graph
+
+type vertexSet map[*Vertex]bool
+
+func (s vertexSet) intersect(vertices []*Vertex) vertexSet {
+	out := make(vertexSet)
+	for _, v := range vertices {
+		if s[v] {
+			out[v] = true
+		}
+	}
+	return out
+}
+
+// Bron-Kerbosch with Tomita pivoting, cliques are sorted by vertex id.
+func MaximalCliques(g *Graph) ([][]*Vertex, error) {
+	if g.Type() != UNDIRECTED {
+		return nil, ErrNotUndirected
+	}
+	neighbors := make(map[*Vertex][]*Vertex, len(g.vertices))
+	p := make(vertexSet, len(g.vertices))
+	for _, v := range g.vertices {
+		neighbors[v] = v.neighbors()
+		p[v] = true
+	}
+
+	cliques := make([][]*Vertex, 0)
+	var expand func(r []*Vertex, p, x vertexSet)
+	expand = func(r []*Vertex, p, x vertexSet) {
+		if len(p) == 0 {
+			if len(x) == 0 {
+				clique := append([]*Vertex{}, r...)
+				sortVertices(clique)
+				cliques = append(cliques, clique)
+			}
+			return
+		}
+
+		var pivot *Vertex
+		best := -1
+		for _, s := range []vertexSet{p, x} {
+			for u := range s {
+				if n := len(p.intersect(neighbors[u])); n > best || (n == best && u.id < pivot.id) {
+					pivot, best = u, n
+				}
+			}
+		}
+		skip := make(vertexSet)
+		for _, u := range neighbors[pivot] {
+			skip[u] = true
+		}
+
+		candidates := make([]*Vertex, 0, len(p))
+		for v := range p {
+			if !skip[v] {
+				candidates = append(candidates, v)
+			}
+		}
+		sortVertices(candidates)
+		for _, v := range candidates {
+			expand(append(r, v), p.intersect(neighbors[v]), x.intersect(neighbors[v]))
+			delete(p, v)
+			x[v] = true
+		}
+	}
+	expand(nil, p, make(vertexSet))
+
+	return cliques, nil
+}
+
+// Cliques keyed by vertex id, each vertex lists the indexes of the maximal cliques it belongs to.
+func CliqueMembership(cliques [][]*Vertex) map[string][]int {
+	out := make(map[string][]int)
+	for i, clique := range cliques {
+		for _, v := range clique {
+			out[v.id] = append(out[v.id], i)
+		}
+	}
+	return out
+}
+
+// Minimum degree greedy heuristic.
+func MaximalIndependentSet(g *Graph) (map[string]bool, error) {
+	if g.Type() != UNDIRECTED {
+		return nil, ErrNotUndirected
+	}
+	remaining := make(vertexSet, len(g.vertices))
+	for _, v := range g.vertices {
+		remaining[v] = true
+	}
+	vertices := g.Vertices()
+	set := make(map[string]bool)
+	for len(remaining) > 0 {
+		var next *Vertex
+		best := 0
+		for _, v := range vertices {
+			if !remaining[v] {
+				continue
+			}
+			if d := len(remaining.intersect(v.neighbors())); next == nil || d < best {
+				next, best = v, d
+			}
+		}
+		set[next.id] = true
+		delete(remaining, next)
+		for _, u := range next.neighbors() {
+			delete(remaining, u)
+		}
+	}
+	return set, nil
+}
@@ -0,0 +1,63 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaximalCliques(t *testing.T) {
+	g := NewUndirected()
+	g.Edge("1", "2")
+	g.Edge("1", "3")
+	g.Edge("2", "3")
+	g.Edge("2", "4")
+	g.Edge("3", "4")
+	g.Edge("4", "5")
+	g.Edge("1", "2")
+	g.Vertex("6")
+
+	cliques, err := MaximalCliques(g)
+	if err != nil {
+		t.Fatalf("Error maximal cliques: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, c := range cliques {
+		found[strings.Join(vertexIds(c), ",")] = true
+	}
+	for _, c := range []string{"1,2,3", "2,3,4", "4,5", "6"} {
+		if !found[c] {
+			t.Errorf("Error missing clique %s: %v", c, found)
+		}
+	}
+	if len(cliques) != 4 {
+		t.Errorf("Error cliques size (4): %d", len(cliques))
+	}
+
+	membership := CliqueMembership(cliques)
+	if n := len(membership["2"]); n != 2 {
+		t.Errorf("Error vertex 2 should be in 2 cliques: %d", n)
+	}
+}
+
+func TestMaximalIndependentSet(t *testing.T) {
+	g := NewUndirected()
+	g.Edge("hub", "a")
+	g.Edge("hub", "b")
+	g.Edge("hub", "c")
+	g.Edge("a", "b")
+	g.Vertex("d")
+
+	set, err := MaximalIndependentSet(g)
+	if err != nil {
+		t.Fatalf("Error independent set: %v", err)
+	}
+	if set["hub"] || !set["c"] || !set["d"] || set["a"] == set["b"] {
+		t.Errorf("Error independent set: %v", set)
+	}
+	for _, e := range g.AllEdges() {
+		from, to := e.Ends()
+		if set[from.id] && set[to.id] {
+			t.Errorf("Error adjacent vertices in set %s-%s", from.id, to.id)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package graph
+
+func reachable(v *Vertex) map[*Vertex]bool {
+	seen := make(map[*Vertex]bool)
+	stack := []*Vertex{v}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, e := range cur.outEdges() {
+			u := e.adjacent(cur)
+			if !seen[u] {
+				seen[u] = true
+				stack = append(stack, u)
+			}
+		}
+	}
+	return seen
+}
+
+// Edge from u to v for every v reachable from u (u to u only when u is on a cycle).
+func TransitiveClosure(g *Graph) (*Graph, error) {
+	if g.Type() != DIRECTED {
+		return nil, ErrNotDirected
+	}
+	c := g.emptyCopy()
+	for _, v := range g.Vertices() {
+		targets := make([]*Vertex, 0)
+		for u := range reachable(v) {
+			targets = append(targets, u)
+		}
+		sortVertices(targets)
+		for _, u := range targets {
+			c.Edge(v.id, u.id)
+		}
+	}
+	return c, nil
+}
+
+// Keeps the original edges not implied by a longer path; parallel edges collapse to the first.
+func TransitiveReduction(g *Graph) (*Graph, error) {
+	order, err := TopologicalSort(g)
+	if err != nil {
+		return nil, err
+	}
+
+	descendants := make(map[*Vertex]map[*Vertex]bool, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		v := order[i]
+		d := make(map[*Vertex]bool)
+		for _, e := range v.outEdges() {
+			u := e.adjacent(v)
+			d[u] = true
+			for w := range descendants[u] {
+				d[w] = true
+			}
+		}
+		descendants[v] = d
+	}
+
+	r := g.emptyCopy()
+	for _, v := range order {
+		indirect := make(map[*Vertex]bool)
+		for _, e := range v.outEdges() {
+			for w := range descendants[e.adjacent(v)] {
+				indirect[w] = true
+			}
+		}
+		kept := make(map[*Vertex]bool)
+		for _, e := range v.outEdges() {
+			u := e.adjacent(v)
+			if indirect[u] || kept[u] {
+				continue
+			}
+			kept[u] = true
+			r.copyEdge(e, v, u)
+		}
+	}
+	return r, nil
+}
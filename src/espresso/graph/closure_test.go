@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestTransitiveClosure(t *testing.T) {
+	g := NewDirected()
+	g.Vertex("a").Label("Role").Set("name", "admin")
+	g.Edge("a", "b")
+	g.Edge("b", "c")
+	g.Edge("c", "b")
+	g.Vertex("d")
+
+	c, err := TransitiveClosure(g)
+	if err != nil {
+		t.Fatalf("Error transitive closure: %v", err)
+	}
+	if n := c.VertexCount(); n != 4 {
+		t.Errorf("Error closure vertices (4): %d", n)
+	}
+	if n := c.EdgeCount(); n != 6 {
+		t.Errorf("Error closure edges (6): %d", n)
+	}
+	for _, pair := range [][2]string{{"a", "b"}, {"a", "c"}, {"b", "b"}, {"b", "c"}, {"c", "b"}, {"c", "c"}} {
+		if e := c.Edges(pair[0], pair[1]); len(e) != 1 {
+			t.Errorf("Error closure edge %s->%s: %d", pair[0], pair[1], len(e))
+		}
+	}
+	if name, _ := c.Vertex("a").GetString("name"); name != "admin" || c.Vertex("a").label != "Role" {
+		t.Errorf("Error closure should copy vertex: %v", c.Vertex("a"))
+	}
+
+	if _, err := TransitiveReduction(g); err != ErrCycle {
+		t.Errorf("Error reduction of cyclic graph: %v", err)
+	}
+	if _, err := TransitiveClosure(NewUndirected()); err != ErrNotDirected {
+		t.Errorf("Error closure of undirected graph: %v", err)
+	}
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	g := NewDirected()
+	g.Edge("a", "b").Label("DEPENDS")
+	g.Edge("a", "c")
+	g.Edge("a", "d")
+	g.Edge("b", "d")
+	g.Edge("c", "d")
+	g.Edge("c", "d")
+	g.Edge("d", "e")
+	g.Edge("a", "e")
+
+	r, err := TransitiveReduction(g)
+	if err != nil {
+		t.Fatalf("Error transitive reduction: %v", err)
+	}
+	if n := r.EdgeCount(); n != 5 {
+		t.Errorf("Error reduction edges (5): %d\n%v", n, r)
+	}
+	if e := r.Edges("a", "b"); len(e) != 1 || e[0].label != "DEPENDS" {
+		t.Errorf("Error reduction should keep edge label: %v", e)
+	}
+	for _, pair := range [][2]string{{"a", "d"}, {"a", "e"}} {
+		if e := r.Edges(pair[0], pair[1]); e != nil {
+			t.Errorf("Error reduction implied edge %s->%s: %d", pair[0], pair[1], len(e))
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"sort"
+)
+
+func smallestFreeColor(v *Vertex, colors map[string]int) int {
+	used := make(map[int]bool)
+	for _, u := range v.neighbors() {
+		if c, ok := colors[u.id]; ok {
+			used[c] = true
+		}
+	}
+	c := 0
+	for used[c] {
+		c++
+	}
+	return c
+}
+
+// Largest degree first, ties by vertex id; a self-loop cannot be colored properly and is ignored.
+func GreedyColoring(g *Graph) (map[string]int, error) {
+	if g.Type() != UNDIRECTED {
+		return nil, ErrNotUndirected
+	}
+	vertices := g.Vertices()
+	degree := make(map[*Vertex]int, len(vertices))
+	for _, v := range vertices {
+		degree[v] = len(v.neighbors())
+	}
+	sort.SliceStable(vertices, func(i, j int) bool { return degree[vertices[i]] > degree[vertices[j]] })
+
+	colors := make(map[string]int, len(vertices))
+	for _, v := range vertices {
+		colors[v.id] = smallestFreeColor(v, colors)
+	}
+	return colors, nil
+}
+
+// Brelaz's DSatur: next vertex has most distinct neighbor colors, then largest degree.
+func DSaturColoring(g *Graph) (map[string]int, error) {
+	if g.Type() != UNDIRECTED {
+		return nil, ErrNotUndirected
+	}
+	vertices := g.Vertices()
+	neighbors := make(map[*Vertex][]*Vertex, len(vertices))
+	saturation := make(map[*Vertex]map[int]bool, len(vertices))
+	for _, v := range vertices {
+		neighbors[v] = v.neighbors()
+		saturation[v] = make(map[int]bool)
+	}
+
+	colors := make(map[string]int, len(vertices))
+	for len(colors) < len(vertices) {
+		var next *Vertex
+		for _, v := range vertices {
+			if _, done := colors[v.id]; done {
+				continue
+			}
+			if next == nil || len(saturation[v]) > len(saturation[next]) ||
+				(len(saturation[v]) == len(saturation[next]) && len(neighbors[v]) > len(neighbors[next])) {
+				next = v
+			}
+		}
+		c := smallestFreeColor(next, colors)
+		colors[next.id] = c
+		for _, u := range neighbors[next] {
+			saturation[u][c] = true
+		}
+	}
+	return colors, nil
+}
+
+func ColorCount(colors map[string]int) int {
+	used := make(map[int]bool)
+	for _, c := range colors {
+		used[c] = true
+	}
+	return len(used)
+}
@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"testing"
+)
+
+func testColoring(t *testing.T, name string, g *Graph, colors map[string]int, max int) {
+	if len(colors) != g.VertexCount() {
+		t.Errorf("(%s) Error all vertices should be colored: %v", name, colors)
+	}
+	for _, e := range g.AllEdges() {
+		from, to := e.Ends()
+		if from != to && colors[from.id] == colors[to.id] {
+			t.Errorf("(%s) Error conflict %s-%s: %d", name, from.id, to.id, colors[from.id])
+		}
+	}
+	if n := ColorCount(colors); n > max {
+		t.Errorf("(%s) Error too many colors (%d): %d", name, max, n)
+	}
+}
+
+func TestColoring(t *testing.T) {
+	// jobs sharing a resource conflict
+	g := NewUndirected()
+	g.Edge("backup", "index")
+	g.Edge("index", "report")
+	g.Edge("report", "backup")
+	g.Edge("report", "mail")
+	g.Edge("mail", "cleanup")
+	g.Edge("cleanup", "mail")
+	g.Edge("cleanup", "cleanup")
+	g.Vertex("idle")
+
+	colors, err := GreedyColoring(g)
+	if err != nil {
+		t.Fatalf("Error greedy coloring: %v", err)
+	}
+	testColoring(t, "greedy", g, colors, 3)
+
+	colors, err = DSaturColoring(g)
+	if err != nil {
+		t.Fatalf("Error dsatur coloring: %v", err)
+	}
+	testColoring(t, "dsatur", g, colors, 3)
+
+	// crown graph fools naive orderings, DSatur colors bipartite graphs optimally
+	crown := NewUndirected()
+	for _, a := range []string{"a1", "a2", "a3", "a4"} {
+		for _, b := range []string{"b1", "b2", "b3", "b4"} {
+			if a[1] != b[1] {
+				crown.Edge(a, b)
+			}
+		}
+	}
+	colors, _ = DSaturColoring(crown)
+	testColoring(t, "crown", crown, colors, 2)
+
+	if _, err := GreedyColoring(NewDirected()); err != ErrNotUndirected {
+		t.Errorf("Error directed graph should be rejected: %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Symmetric weight matrix over vertex indexes, a[i][i] counts self-loops twice.
+type weightMatrix struct {
+	vertices []*Vertex
+	a        []map[int]float64
+	degree   []float64
+	total    float64
+}
+
+func newWeightMatrix(g *Graph, weight string) (*weightMatrix, error) {
+	weights, err := edgeWeights(g, weight)
+	if err != nil {
+		return nil, err
+	}
+	vertices := g.Vertices()
+	index := make(map[*Vertex]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	m := &weightMatrix{
+		vertices: vertices,
+		a:        make([]map[int]float64, len(vertices)),
+	}
+	for i := range m.a {
+		m.a[i] = make(map[int]float64)
+	}
+	for _, e := range g.AllEdges() {
+		from, to := e.Ends()
+		i, j := index[from], index[to]
+		m.a[i][j] += weights[e]
+		m.a[j][i] += weights[e]
+	}
+	m.sums()
+	return m, nil
+}
+
+func (m *weightMatrix) sums() {
+	m.degree = make([]float64, len(m.a))
+	m.total = 0
+	for i, row := range m.a {
+		for _, w := range row {
+			m.degree[i] += w
+		}
+		m.total += m.degree[i]
+	}
+}
+
+func (m *weightMatrix) modularity(community []int) float64 {
+	if m.total == 0 {
+		return 0
+	}
+	in := make(map[int]float64)
+	tot := make(map[int]float64)
+	for i, row := range m.a {
+		tot[community[i]] += m.degree[i]
+		for j, w := range row {
+			if community[i] == community[j] {
+				in[community[i]] += w
+			}
+		}
+	}
+	q := 0.0
+	for c, t := range tot {
+		q += in[c]/m.total - (t/m.total)*(t/m.total)
+	}
+	return q
+}
+
+// Moves vertices between communities while modularity improves.
+func (m *weightMatrix) localMoves(community []int) bool {
+	tot := make([]float64, len(m.a))
+	for i := range m.a {
+		tot[community[i]] += m.degree[i]
+	}
+	improved := false
+	for moved := true; moved; {
+		moved = false
+		for i, row := range m.a {
+			current := community[i]
+			tot[current] -= m.degree[i]
+
+			links := make(map[int]float64)
+			for j, w := range row {
+				if j != i {
+					links[community[j]] += w
+				}
+			}
+			candidates := make([]int, 0, len(links))
+			for c := range links {
+				candidates = append(candidates, c)
+			}
+			sort.Ints(candidates)
+
+			best, bestGain := current, links[current]-tot[current]*m.degree[i]/m.total
+			for _, c := range candidates {
+				gain := links[c] - tot[c]*m.degree[i]/m.total
+				if gain > bestGain+1e-12 {
+					best, bestGain = c, gain
+				}
+			}
+
+			tot[best] += m.degree[i]
+			if best != current {
+				community[i] = best
+				moved = true
+				improved = true
+			}
+		}
+	}
+	return improved
+}
+
+func (m *weightMatrix) aggregate(community []int) (*weightMatrix, []int) {
+	index, n := renumber(community)
+	a := make([]map[int]float64, n)
+	for i := range a {
+		a[i] = make(map[int]float64)
+	}
+	for i, row := range m.a {
+		for j, w := range row {
+			a[index[i]][index[j]] += w
+		}
+	}
+	agg := &weightMatrix{a: a}
+	agg.sums()
+	return agg, index
+}
+
+func renumber(community []int) ([]int, int) {
+	ids := make(map[int]int)
+	index := make([]int, len(community))
+	for i, c := range community {
+		id, ok := ids[c]
+		if !ok {
+			id = len(ids)
+			ids[c] = id
+		}
+		index[i] = id
+	}
+	return index, len(ids)
+}
+
+func (m *weightMatrix) assign(community []int, property string) map[string]int {
+	index, _ := renumber(community)
+	out := make(map[string]int, len(m.vertices))
+	for i, v := range m.vertices {
+		out[v.id] = index[i]
+		if property != "" {
+			v.Set(property, index[i])
+		}
+	}
+	return out
+}
+
+func Modularity(g *Graph, communities map[string]int, weight string) (float64, error) {
+	m, err := newWeightMatrix(g, weight)
+	if err != nil {
+		return 0, err
+	}
+	community := make([]int, len(m.vertices))
+	for i, v := range m.vertices {
+		c, ok := communities[v.id]
+		if !ok {
+			c = -1 - i
+		}
+		community[i] = c
+	}
+	return m.modularity(community), nil
+}
+
+// Louvain modularity optimization, edge direction is ignored.
+func Louvain(g *Graph, weight, property string) (map[string]int, error) {
+	m, err := newWeightMatrix(g, weight)
+	if err != nil {
+		return nil, err
+	}
+	membership := make([]int, len(m.vertices))
+	for i := range membership {
+		membership[i] = i
+	}
+	if m.total == 0 {
+		return m.assign(membership, property), nil
+	}
+
+	level := m
+	for {
+		community := make([]int, len(level.a))
+		for i := range community {
+			community[i] = i
+		}
+		if !level.localMoves(community) {
+			break
+		}
+		next, index := level.aggregate(community)
+		for i, c := range membership {
+			membership[i] = index[c]
+		}
+		level = next
+	}
+	return m.assign(membership, property), nil
+}
+
+// Asynchronous label propagation, vertices are visited in a seeded random order.
+func LabelPropagation(g *Graph, weight, property string, seed int64) (map[string]int, error) {
+	m, err := newWeightMatrix(g, weight)
+	if err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(seed))
+	label := make([]int, len(m.a))
+	for i := range label {
+		label[i] = i
+	}
+
+	best := func(i int) []int {
+		counts := make(map[int]float64)
+		for j, w := range m.a[i] {
+			if j != i {
+				counts[label[j]] += w
+			}
+		}
+		max := 0.0
+		labels := make([]int, 0)
+		for l, w := range counts {
+			if w > max {
+				max = w
+				labels = labels[:0]
+			}
+			if w == max {
+				labels = append(labels, l)
+			}
+		}
+		sort.Ints(labels)
+		return labels
+	}
+
+	for round := 0; round < 100; round++ {
+		changed := false
+		for _, i := range r.Perm(len(m.a)) {
+			labels := best(i)
+			if len(labels) == 0 || containsInt(labels, label[i]) {
+				continue
+			}
+			label[i] = labels[r.Intn(len(labels))]
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	return m.assign(label, property), nil
+}
+
+func containsInt(s []int, x int) bool {
+	for _, y := range s {
+		if y == x {
+			return true
+		}
+	}
+	return false
+}
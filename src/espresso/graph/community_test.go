@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+// Two triangles joined by a single weak edge.
+func barbellGraph() *Graph {
+	g := NewUndirected()
+	for _, pair := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"x", "y"}, {"y", "z"}, {"z", "x"}} {
+		g.Edge(pair[0], pair[1]).Set("weight", 3)
+	}
+	g.Edge("c", "x").Set("weight", 1)
+	return g
+}
+
+func testCommunities(t *testing.T, name string, g *Graph, communities map[string]int) {
+	if len(communities) != 6 {
+		t.Fatalf("(%s) Error communities size (6): %d", name, len(communities))
+	}
+	if communities["a"] != communities["b"] || communities["b"] != communities["c"] {
+		t.Errorf("(%s) Error a, b, c should share community: %v", name, communities)
+	}
+	if communities["x"] != communities["y"] || communities["y"] != communities["z"] {
+		t.Errorf("(%s) Error x, y, z should share community: %v", name, communities)
+	}
+	if communities["a"] == communities["x"] {
+		t.Errorf("(%s) Error triangles should be split: %v", name, communities)
+	}
+	if c, err := Prop[int](g.Vertex("y"), name); err != nil || c != communities["y"] {
+		t.Errorf("(%s) Error community property: %d, %v", name, c, err)
+	}
+}
+
+func TestModularity(t *testing.T) {
+	g := barbellGraph()
+
+	q, err := Modularity(g, map[string]int{"a": 0, "b": 0, "c": 0, "x": 1, "y": 1, "z": 1}, "weight")
+	if err != nil {
+		t.Fatalf("Error modularity: %v", err)
+	}
+	// in = 18 per side, tot = 19 per side, 2m = 38
+	if expected := 2 * (18.0/38 - 0.25); math.Abs(q-expected) > 1e-9 {
+		t.Errorf("Error modularity (%g): %g", expected, q)
+	}
+
+	single, _ := Modularity(g, map[string]int{}, "weight")
+	if single >= q {
+		t.Errorf("Error singleton modularity should be lower: %g >= %g", single, q)
+	}
+}
+
+func TestLouvain(t *testing.T) {
+	g := barbellGraph()
+	communities, err := Louvain(g, "weight", "louvain")
+	if err != nil {
+		t.Fatalf("Error louvain: %v", err)
+	}
+	testCommunities(t, "louvain", g, communities)
+
+	empty, _ := Louvain(NewUndirected(), "", "")
+	if len(empty) != 0 {
+		t.Errorf("Error louvain empty graph: %v", empty)
+	}
+}
+
+func TestLabelPropagation(t *testing.T) {
+	g := barbellGraph()
+	communities, err := LabelPropagation(g, "weight", "lpa", 7)
+	if err != nil {
+		t.Fatalf("Error label propagation: %v", err)
+	}
+	testCommunities(t, "lpa", g, communities)
+
+	again, _ := LabelPropagation(barbellGraph(), "weight", "", 7)
+	for id, c := range communities {
+		if again[id] != c {
+			t.Errorf("Error label propagation should be reproducible: %v, %v", communities, again)
+			break
+		}
+	}
+}
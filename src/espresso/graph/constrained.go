@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"sort"
+)
+
+type PathConstraints struct {
+	MaxHops         int
+	RequireLabels   []string
+	ForbidLabels    []string
+	EdgeLabels      []string
+	VertexPredicate func(v *Vertex) bool
+	EdgePredicate   func(e *Edge) bool
+	Weight          string
+	Limit           int
+}
+
+type pathSearch struct {
+	c        *PathConstraints
+	dst      *Vertex
+	weights  map[*Edge]float64
+	forbid   map[string]bool
+	edges    map[string]bool
+	reach    map[*Vertex]bool
+	labels   map[string]int
+	missing  int
+	visited  map[*Vertex]bool
+	vertices []*Vertex
+	path     []*Edge
+	results  []*Path
+}
+
+// Depth-first enumeration of simple paths, sorted by weight and then hops.
+// Lighter edges are followed first and a Limit stops the search once that many
+// paths are found, so they are not necessarily the lightest ones.
+func ConstrainedPaths(g *Graph, from, to string, c PathConstraints) ([]*Path, error) {
+	src, ok1 := g.getVertex(from)
+	dst, ok2 := g.getVertex(to)
+	if !ok1 || !ok2 {
+		return nil, ErrVertexNotFound
+	}
+	weights, err := edgeWeights(g, c.Weight)
+	if err != nil {
+		return nil, err
+	}
+	s := &pathSearch{
+		c:       &c,
+		dst:     dst,
+		weights: weights,
+		forbid:  make(map[string]bool),
+		labels:  make(map[string]int),
+		visited: make(map[*Vertex]bool),
+	}
+	for _, label := range c.ForbidLabels {
+		s.forbid[label] = true
+	}
+	if len(c.EdgeLabels) > 0 {
+		s.edges = make(map[string]bool)
+		for _, label := range c.EdgeLabels {
+			s.edges[label] = true
+		}
+	}
+	for _, label := range c.RequireLabels {
+		if _, ok := s.labels[label]; !ok {
+			s.labels[label] = 0
+			s.missing++
+		}
+	}
+
+	if s.acceptVertex(dst) {
+		s.reach = s.reaching(dst)
+	}
+	found := make(map[string]bool)
+	for v := range s.reach {
+		found[v.label] = true
+	}
+	for label := range s.labels {
+		if !found[label] {
+			return nil, nil
+		}
+	}
+	if s.reach[src] {
+		s.visit(src, 0)
+	}
+
+	sort.SliceStable(s.results, func(i, j int) bool {
+		if s.results[i].Weight != s.results[j].Weight {
+			return s.results[i].Weight < s.results[j].Weight
+		}
+		return len(s.results[i].Edges) < len(s.results[j].Edges)
+	})
+	return s.results, nil
+}
+
+func (s *pathSearch) acceptVertex(v *Vertex) bool {
+	if s.forbid[v.label] {
+		return false
+	}
+	return s.c.VertexPredicate == nil || s.c.VertexPredicate(v)
+}
+
+func (s *pathSearch) acceptEdge(e *Edge) bool {
+	if s.edges != nil && !s.edges[e.label] {
+		return false
+	}
+	return s.c.EdgePredicate == nil || s.c.EdgePredicate(e)
+}
+
+// Accepted vertices with an accepted path to dst, found backwards from it.
+func (s *pathSearch) reaching(dst *Vertex) map[*Vertex]bool {
+	reach := map[*Vertex]bool{dst: true}
+	queue := []*Vertex{dst}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, e := range v.inEdges() {
+			u := e.tail(v)
+			if !reach[u] && s.acceptEdge(e) && s.acceptVertex(u) {
+				reach[u] = true
+				queue = append(queue, u)
+			}
+		}
+	}
+	return reach
+}
+
+// Counts the labels still required along the path, delta is 1 on enter and -1 on leave.
+func (s *pathSearch) label(v *Vertex, delta int) {
+	n, ok := s.labels[v.label]
+	if !ok {
+		return
+	}
+	switch {
+	case n == 0 && delta > 0:
+		s.missing--
+	case n == 1 && delta < 0:
+		s.missing++
+	}
+	s.labels[v.label] = n + delta
+}
+
+// Reports whether the search is done.
+func (s *pathSearch) visit(v *Vertex, weight float64) bool {
+	s.visited[v] = true
+	s.vertices = append(s.vertices, v)
+	s.label(v, 1)
+	defer func() {
+		s.visited[v] = false
+		s.vertices = s.vertices[:len(s.vertices)-1]
+		s.label(v, -1)
+	}()
+
+	if v == s.dst {
+		if s.missing == 0 {
+			s.record(weight)
+		}
+		return s.c.Limit > 0 && len(s.results) >= s.c.Limit
+	}
+	if s.c.MaxHops > 0 {
+		// every further hop visits one vertex and so covers at most one missing label
+		if left := s.c.MaxHops - len(s.path); left <= 0 || s.missing > left {
+			return false
+		}
+	}
+
+	edges := v.outEdges()
+	sort.SliceStable(edges, func(i, j int) bool {
+		return s.weights[edges[i]] < s.weights[edges[j]]
+	})
+	for _, e := range edges {
+		u := e.adjacent(v)
+		if s.visited[u] || !s.reach[u] || !s.acceptEdge(e) {
+			continue
+		}
+		s.path = append(s.path, e)
+		done := s.visit(u, weight+s.weights[e])
+		s.path = s.path[:len(s.path)-1]
+		if done {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *pathSearch) record(weight float64) {
+	s.results = append(s.results, &Path{
+		Vertices: append([]*Vertex{}, s.vertices...),
+		Edges:    append([]*Edge{}, s.path...),
+		Weight:   weight,
+	})
+}
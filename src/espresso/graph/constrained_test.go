@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestConstrainedPaths(t *testing.T) {
+	g := routeGraph()
+	g.Vertex("D").Label("Toll")
+	g.Vertex("G").Label("Fuel")
+	g.Edge("E", "G").Label("ferry").Set("km", 1)
+
+	paths, err := ConstrainedPaths(g, "C", "H", PathConstraints{Weight: "km"})
+	if err != nil {
+		t.Fatalf("Error constrained paths: %v", err)
+	}
+	if n := len(paths); n != 8 {
+		t.Errorf("Error unconstrained paths (8): %d", n)
+	}
+
+	paths, _ = ConstrainedPaths(g, "C", "H", PathConstraints{MaxHops: 3})
+	for _, p := range paths {
+		if p.Len() > 3 {
+			t.Errorf("Error path exceeds max hops: %s", p)
+		}
+	}
+
+	paths, _ = ConstrainedPaths(g, "C", "H", PathConstraints{ForbidLabels: []string{"Toll"}})
+	for _, p := range paths {
+		for _, v := range p.Vertices {
+			if v.label == "Toll" {
+				t.Errorf("Error path with forbidden label: %s", p)
+			}
+		}
+	}
+
+	paths, _ = ConstrainedPaths(g, "C", "H", PathConstraints{
+		RequireLabels: []string{"Fuel"},
+		EdgeLabels:    []string{"ferry", ""},
+		Weight:        "km",
+	})
+	if len(paths) == 0 || paths[0].Weight != 5 {
+		t.Fatalf("Error required fuel stop by ferry: %v", paths)
+	}
+
+	paths, _ = ConstrainedPaths(g, "C", "H", PathConstraints{
+		EdgePredicate: func(e *Edge) bool { return PropOr(e, "km", 0) <= 2 },
+		Weight:        "km",
+		Limit:         1,
+	})
+	if len(paths) != 1 || paths[0].Weight != 5 {
+		t.Errorf("Error short hops only: %v", paths)
+	}
+	for _, e := range paths[0].Edges {
+		if PropOr(e, "km", 0) > 2 {
+			t.Errorf("Error path with long hop: %s", paths[0])
+		}
+	}
+}
+
+func TestConstrainedPathsLimit(t *testing.T) {
+	// complete graph: enumerating every simple path first would not finish
+	g := NewDirected()
+	for i := 0; i < 30; i++ {
+		for j := 0; j < 30; j++ {
+			if i != j {
+				g.Edge(strconv.Itoa(i), strconv.Itoa(j))
+			}
+		}
+	}
+	paths, err := ConstrainedPaths(g, "0", "1", PathConstraints{Limit: 3})
+	if err != nil || len(paths) != 3 {
+		t.Fatalf("Error limited paths (3): %v %v", paths, err)
+	}
+	if paths[0].Len() != 1 {
+		t.Errorf("Error direct edge should come first: %s", paths[0])
+	}
+
+	g.Vertex("29").Label("Hub")
+	paths, _ = ConstrainedPaths(g, "0", "1", PathConstraints{RequireLabels: []string{"Hub", "None"}})
+	if len(paths) != 0 {
+		t.Errorf("Error label missing from the graph should find nothing: %v", paths)
+	}
+	paths, _ = ConstrainedPaths(g, "0", "1", PathConstraints{RequireLabels: []string{"Hub"}, MaxHops: 2})
+	if len(paths) != 1 || paths[0].Vertices[1].Id() != "29" {
+		t.Errorf("Error path through hub within 2 hops: %v", paths)
+	}
+}
@@ -0,0 +1,104 @@
+package graph
+
+// Batagelj-Zaversnik bin sort peeling. Degrees count distinct neighbors, so
+// direction, parallel edges and self-loops are ignored. Returns vertices in
+// removal order with their core numbers.
+func coreDecomposition(g *Graph) ([]*Vertex, map[*Vertex]int) {
+	vertices := g.Vertices()
+	n := len(vertices)
+	index := make(map[*Vertex]int, n)
+	for i, v := range vertices {
+		index[v] = i
+	}
+	neighbors := make([][]int, n)
+	deg := make([]int, n)
+	maxDeg := 0
+	for i, v := range vertices {
+		for _, u := range v.neighbors() {
+			neighbors[i] = append(neighbors[i], index[u])
+		}
+		deg[i] = len(neighbors[i])
+		maxDeg = max(maxDeg, deg[i])
+	}
+
+	// bin[d] is the start of degree d in vert, pos the place of each vertex
+	bin := make([]int, maxDeg+1)
+	for _, d := range deg {
+		bin[d]++
+	}
+	start := 0
+	for d, count := range bin {
+		bin[d] = start
+		start += count
+	}
+	vert := make([]int, n)
+	pos := make([]int, n)
+	for i, d := range deg {
+		pos[i] = bin[d]
+		vert[pos[i]] = i
+		bin[d]++
+	}
+	for d := maxDeg; d > 0; d-- {
+		bin[d] = bin[d-1]
+	}
+	if n > 0 {
+		bin[0] = 0
+	}
+
+	order := make([]*Vertex, n)
+	core := make(map[*Vertex]int, n)
+	for i, v := range vert {
+		order[i] = vertices[v]
+		core[vertices[v]] = deg[v]
+		for _, u := range neighbors[v] {
+			if deg[u] <= deg[v] {
+				continue
+			}
+			// swap u with the first vertex of its bin and shrink the bin
+			du, pu := deg[u], pos[u]
+			pw := bin[du]
+			w := vert[pw]
+			if u != w {
+				pos[u], pos[w] = pw, pu
+				vert[pu], vert[pw] = w, u
+			}
+			bin[du]++
+			deg[u]--
+		}
+	}
+	return order, core
+}
+
+// Largest k such that the vertex belongs to a subgraph of minimum degree k.
+func CoreNumbers(g *Graph) map[string]int {
+	_, core := coreDecomposition(g)
+	out := make(map[string]int, len(core))
+	for v, k := range core {
+		out[v.id] = k
+	}
+	return out
+}
+
+// Induced subgraph of the vertices with core number at least k, as a new graph
+// with the labels, properties and edge ids of g.
+func KCore(g *Graph, k int) *Graph {
+	_, core := coreDecomposition(g)
+	keep := make(map[*Vertex]bool, len(core))
+	for v, c := range core {
+		keep[v] = c >= k
+	}
+	return g.induced(keep)
+}
+
+// Vertex ids in an order where each vertex has at most degeneracy neighbors
+// later in the order, and the degeneracy (the largest core number).
+func DegeneracyOrdering(g *Graph) ([]string, int) {
+	order, core := coreDecomposition(g)
+	ids := make([]string, len(order))
+	degeneracy := 0
+	for i, v := range order {
+		ids[i] = v.id
+		degeneracy = max(degeneracy, core[v])
+	}
+	return ids, degeneracy
+}
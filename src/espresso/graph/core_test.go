@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestCoreNumbers(t *testing.T) {
+	// 4-clique a-d, triangle d-e-f hanging off d, path f-g-h and isolated i
+	g := NewUndirected()
+	for _, p := range [][2]string{
+		{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"},
+		{"d", "e"}, {"e", "f"}, {"f", "d"}, {"f", "g"}, {"g", "h"},
+	} {
+		g.Edge(p[0], p[1]).Label("L").Set("w", 1)
+	}
+	g.Edge("a", "b")
+	g.Edge("h", "h")
+	g.Vertex("i").Label("Lonely")
+
+	expected := map[string]int{"a": 3, "b": 3, "c": 3, "d": 3, "e": 2, "f": 2, "g": 1, "h": 1, "i": 0}
+	core := CoreNumbers(g)
+	for id, k := range expected {
+		if core[id] != k {
+			t.Errorf("Error core number of %s: %v != %v", id, core[id], k)
+		}
+	}
+
+	order, degeneracy := DegeneracyOrdering(g)
+	if degeneracy != 3 || len(order) != 9 {
+		t.Errorf("Error degeneracy: %v %v", degeneracy, order)
+	}
+	at := make(map[string]int)
+	for i, id := range order {
+		at[id] = i
+	}
+	for _, id := range order {
+		later := 0
+		for _, u := range g.vertices[id].neighbors() {
+			if at[u.id] > at[id] {
+				later++
+			}
+		}
+		if later > degeneracy {
+			t.Errorf("Error ordering, %s has %d later neighbors: %v", id, later, order)
+		}
+	}
+
+	c := KCore(g, 2)
+	if c.Type() != UNDIRECTED || c.VertexCount() != 6 || c.EdgeCount() != 10 {
+		t.Errorf("Error 2-core: %v", c)
+	}
+	if e := c.Edges("d", "e"); len(e) != 1 || e[0].GetLabel() != "L" {
+		t.Errorf("Error 2-core edge: %v", e)
+	}
+	if e := g.Edges("a", "c")[0]; c.EdgeById(e.Id()) == nil {
+		t.Errorf("Error 2-core edge id: %v", e.Id())
+	}
+	if _, ok := c.getVertex("g"); ok {
+		t.Errorf("Error 2-core has g: %v", c)
+	}
+	if c := KCore(g, 4); c.VertexCount() != 0 {
+		t.Errorf("Error empty 4-core: %v", c)
+	}
+	if c := KCore(g, 0); c.VertexCount() != 9 || c.EdgeCount() != g.EdgeCount() {
+		t.Errorf("Error 0-core: %v", c)
+	}
+
+	d := NewDirected()
+	d.Edge("1", "2")
+	d.Edge("2", "3")
+	d.Edge("3", "1")
+	d.Edge("3", "4")
+	if core := CoreNumbers(d); core["1"] != 2 || core["4"] != 1 {
+		t.Errorf("Error directed core numbers: %v", core)
+	}
+	if order, k := DegeneracyOrdering(New()); len(order) != 0 || k != 0 {
+		t.Errorf("Error empty ordering: %v %v", order, k)
+	}
+}
@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"errors"
+)
+
+var ErrCycle = errors.New("graph has a cycle")
+
+// Kahn's algorithm, sources taken in vertex id order.
+func TopologicalSort(g *Graph) ([]*Vertex, error) {
+	if g.Type() != DIRECTED {
+		return nil, ErrNotDirected
+	}
+	indegree := make(map[*Vertex]int, len(g.vertices))
+	for _, e := range g.AllEdges() {
+		from, to := e.Ends()
+		indegree[to]++
+		if from == to {
+			return nil, ErrCycle
+		}
+	}
+	queue := make([]*Vertex, 0, len(g.vertices))
+	for _, v := range g.Vertices() {
+		if indegree[v] == 0 {
+			queue = append(queue, v)
+		}
+	}
+	order := make([]*Vertex, 0, len(g.vertices))
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		order = append(order, v)
+		for _, e := range v.outEdges() {
+			u := e.adjacent(v)
+			indegree[u]--
+			if indegree[u] == 0 {
+				queue = append(queue, u)
+			}
+		}
+	}
+	if len(order) != len(g.vertices) {
+		return nil, ErrCycle
+	}
+	return order, nil
+}
+
+func IsAcyclic(g *Graph) bool {
+	_, err := TopologicalSort(g)
+	return err == nil
+}
@@ -0,0 +1,42 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewDirected()
+	g.Edge("shirt", "tie")
+	g.Edge("tie", "jacket")
+	g.Edge("pants", "shoes")
+	g.Edge("pants", "belt")
+	g.Edge("belt", "jacket")
+	g.Edge("shirt", "belt")
+	g.Vertex("watch")
+
+	order, err := TopologicalSort(g)
+	if err != nil {
+		t.Fatalf("Error topological sort: %v", err)
+	}
+	if len(order) != g.VertexCount() {
+		t.Fatalf("Error topological sort size (%d): %d", g.VertexCount(), len(order))
+	}
+	position := make(map[*Vertex]int)
+	for i, v := range order {
+		position[v] = i
+	}
+	for _, e := range g.AllEdges() {
+		from, to := e.Ends()
+		if position[from] > position[to] {
+			t.Errorf("Error topological order %s before %s: %v", to.id, from.id, vertexIds(order))
+		}
+	}
+
+	g.Edge("jacket", "shirt")
+	if _, err := TopologicalSort(g); err != ErrCycle {
+		t.Errorf("Error cyclic graph: %v", err)
+	}
+	if IsAcyclic(g) {
+		t.Errorf("Error cyclic graph reported acyclic")
+	}
+}
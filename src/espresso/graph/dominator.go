@@ -0,0 +1,198 @@
+package graph
+
+import (
+	"sort"
+)
+
+// Dominator tree of the vertices reachable from Root; a vertex dominates
+// another when every path from Root to it passes through the first.
+type DominatorTree struct {
+	Root      string
+	idom      map[string]string
+	children  map[string][]string
+	pre, post map[string]int
+}
+
+func successors(v *Vertex) []*Vertex {
+	edges := v.outEdges()
+	out := make([]*Vertex, len(edges))
+	for i, e := range edges {
+		out[i] = e.adjacent(v)
+	}
+	return out
+}
+
+func predecessors(v *Vertex) []*Vertex {
+	edges := v.inEdges()
+	out := make([]*Vertex, len(edges))
+	for i, e := range edges {
+		out[i] = e.tail(v)
+	}
+	return out
+}
+
+// Lengauer-Tarjan with path compression over the depth-first preorder from
+// root. Returns the immediate dominator of every reachable vertex but root.
+func lengauerTarjan(root *Vertex, succ, pred func(*Vertex) []*Vertex) map[*Vertex]*Vertex {
+	num := make(map[*Vertex]int)
+	var vertex []*Vertex
+	var parent []int
+	visit := func(v *Vertex, p int) {
+		num[v] = len(vertex)
+		vertex = append(vertex, v)
+		parent = append(parent, p)
+	}
+	type frame struct {
+		v    *Vertex
+		next []*Vertex
+	}
+	visit(root, -1)
+	stack := []frame{{root, succ(root)}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if len(top.next) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		w := top.next[0]
+		top.next = top.next[1:]
+		if _, seen := num[w]; !seen {
+			visit(w, num[top.v])
+			stack = append(stack, frame{w, succ(w)})
+		}
+	}
+
+	n := len(vertex)
+	semi := make([]int, n)
+	label := make([]int, n)
+	ancestor := make([]int, n)
+	idom := make([]int, n)
+	bucket := make([][]int, n)
+	for i := range semi {
+		semi[i], label[i], ancestor[i] = i, i, -1
+	}
+	var compress func(v int)
+	compress = func(v int) {
+		a := ancestor[v]
+		if ancestor[a] < 0 {
+			return
+		}
+		compress(a)
+		if semi[label[a]] < semi[label[v]] {
+			label[v] = label[a]
+		}
+		ancestor[v] = ancestor[a]
+	}
+	eval := func(v int) int {
+		if ancestor[v] < 0 {
+			return v
+		}
+		compress(v)
+		return label[v]
+	}
+
+	for w := n - 1; w > 0; w-- {
+		for _, pv := range pred(vertex[w]) {
+			if v, ok := num[pv]; ok {
+				if u := eval(v); semi[u] < semi[w] {
+					semi[w] = semi[u]
+				}
+			}
+		}
+		bucket[semi[w]] = append(bucket[semi[w]], w)
+		p := parent[w]
+		ancestor[w] = p
+		for _, v := range bucket[p] {
+			if u := eval(v); semi[u] < semi[v] {
+				idom[v] = u
+			} else {
+				idom[v] = p
+			}
+		}
+		bucket[p] = nil
+	}
+	out := make(map[*Vertex]*Vertex, n)
+	for w := 1; w < n; w++ {
+		if idom[w] != semi[w] {
+			idom[w] = idom[idom[w]]
+		}
+		out[vertex[w]] = vertex[idom[w]]
+	}
+	return out
+}
+
+func newDominatorTree(g *Graph, root string, succ, pred func(*Vertex) []*Vertex) (*DominatorTree, error) {
+	if g.Type() != DIRECTED {
+		return nil, ErrNotDirected
+	}
+	r, ok := g.getVertex(root)
+	if !ok {
+		return nil, ErrVertexNotFound
+	}
+	t := &DominatorTree{
+		Root:     root,
+		idom:     make(map[string]string),
+		children: make(map[string][]string),
+		pre:      make(map[string]int),
+		post:     make(map[string]int),
+	}
+	for v, d := range lengauerTarjan(r, succ, pred) {
+		t.idom[v.id] = d.id
+		t.children[d.id] = append(t.children[d.id], v.id)
+	}
+	for _, c := range t.children {
+		sort.Strings(c)
+	}
+
+	// pre and post order numbers answer Dominates in constant time
+	clock := 0
+	type frame struct {
+		id   string
+		next int
+	}
+	stack := []frame{{root, 0}}
+	t.pre[root] = clock
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if c := t.children[top.id]; top.next < len(c) {
+			clock++
+			t.pre[c[top.next]] = clock
+			top.next++
+			stack = append(stack, frame{c[top.next-1], 0})
+			continue
+		}
+		clock++
+		t.post[top.id] = clock
+		stack = stack[:len(stack)-1]
+	}
+	return t, nil
+}
+
+// Dominators of a control-flow graph from its entry vertex.
+func Dominators(g *Graph, entry string) (*DominatorTree, error) {
+	return newDominatorTree(g, entry, successors, predecessors)
+}
+
+// Dominators of the reversed graph from its exit vertex; graphs with several
+// exits need a virtual exit joined to all of them.
+func PostDominators(g *Graph, exit string) (*DominatorTree, error) {
+	return newDominatorTree(g, exit, predecessors, successors)
+}
+
+// Immediate dominator, false for the root and unreachable vertices.
+func (t *DominatorTree) Idom(id string) (string, bool) {
+	d, ok := t.idom[id]
+	return d, ok
+}
+
+// Vertices immediately dominated by id, sorted.
+func (t *DominatorTree) Children(id string) []string {
+	return append([]string{}, t.children[id]...)
+}
+
+// Every vertex dominates itself; false when either vertex is unreachable.
+func (t *DominatorTree) Dominates(a, b string) bool {
+	pa, ok1 := t.pre[a]
+	pb, ok2 := t.pre[b]
+	return ok1 && ok2 && pa <= pb && t.post[b] <= t.post[a]
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// Whether to is reachable from from without passing through skip.
+func reachableWithout(g *Graph, from, to, skip string) bool {
+	if from == skip {
+		return false
+	}
+	seen := map[string]bool{from: true}
+	queue := []*Vertex{g.vertices[from]}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if v.id == to {
+			return true
+		}
+		for _, u := range successors(v) {
+			if !seen[u.id] && u.id != skip {
+				seen[u.id] = true
+				queue = append(queue, u)
+			}
+		}
+	}
+	return false
+}
+
+func TestDominators(t *testing.T) {
+	// example of the Lengauer-Tarjan paper
+	g := NewDirected()
+	for _, p := range []string{
+		"RA", "RB", "RC", "AD", "BA", "BD", "BE", "CF", "CG", "DL", "EH", "FI",
+		"GI", "GJ", "HE", "HK", "IK", "JI", "KR", "KI", "LH",
+	} {
+		g.Edge(p[:1], p[1:])
+	}
+	g.Edge("X", "A")
+
+	d, err := Dominators(g, "R")
+	if err != nil {
+		t.Fatalf("Error dominators: %v", err)
+	}
+	expected := map[string]string{
+		"A": "R", "B": "R", "C": "R", "D": "R", "E": "R", "F": "C",
+		"G": "C", "H": "R", "I": "R", "J": "G", "K": "R", "L": "D",
+	}
+	for v, idom := range expected {
+		if i, ok := d.Idom(v); !ok || i != idom {
+			t.Errorf("Error idom of %s: %v != %v", v, i, idom)
+		}
+	}
+	if _, ok := d.Idom("R"); ok {
+		t.Errorf("Error root has an idom")
+	}
+	if _, ok := d.Idom("X"); ok || d.Dominates("X", "A") || d.Dominates("R", "X") {
+		t.Errorf("Error unreachable vertex in tree")
+	}
+	if c := fmt.Sprint(d.Children("C")); c != "[F G]" {
+		t.Errorf("Error children of C: %v", c)
+	}
+	if !d.Dominates("R", "J") || !d.Dominates("C", "J") || !d.Dominates("J", "J") || d.Dominates("G", "I") || d.Dominates("D", "H") {
+		t.Errorf("Error dominates")
+	}
+
+	// post-dominators of an if-else
+	cfg := NewDirected()
+	cfg.Edge("entry", "cond")
+	cfg.Edge("cond", "then")
+	cfg.Edge("cond", "else")
+	cfg.Edge("then", "join")
+	cfg.Edge("else", "join")
+	cfg.Edge("join", "exit")
+	p, err := PostDominators(cfg, "exit")
+	if err != nil {
+		t.Fatalf("Error post-dominators: %v", err)
+	}
+	if i, _ := p.Idom("cond"); i != "join" || !p.Dominates("join", "then") || p.Dominates("then", "cond") {
+		t.Errorf("Error post-dominators: %v", i)
+	}
+	if d, _ := Dominators(cfg, "entry"); !d.Dominates("cond", "join") || d.Dominates("then", "join") {
+		t.Errorf("Error if-else dominators")
+	}
+
+	// random graphs against the definition
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 20; round++ {
+		g := NewDirected()
+		for i := 0; i < 15; i++ {
+			g.Vertex(fmt.Sprint(i))
+		}
+		for i := 0; i < 30; i++ {
+			g.Edge(fmt.Sprint(r.Intn(15)), fmt.Sprint(r.Intn(15)))
+		}
+		d, _ := Dominators(g, "0")
+		for a := range g.vertices {
+			for b := range g.vertices {
+				reachable := reachableWithout(g, "0", b, "")
+				expected := reachable && (a == b || a == "0" || !reachableWithout(g, "0", b, a))
+				if d.Dominates(a, b) != expected {
+					t.Errorf("Error random graph %d: %s dominates %s %v", round, a, b, !expected)
+				}
+			}
+		}
+	}
+
+	if _, err := Dominators(NewUndirected(), "a"); err != ErrNotDirected {
+		t.Errorf("Error undirected dominators: %v", err)
+	}
+	if _, err := Dominators(g, "none"); err != ErrVertexNotFound {
+		t.Errorf("Error missing entry: %v", err)
+	}
+}
@@ -0,0 +1,343 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+var (
+	ErrNotEulerian = errors.New("graph is not eulerian")
+	ErrMixedEdges  = errors.New("graph has both directed and undirected edges")
+)
+
+// One traversal of an edge; undirected traversals are listed at both ends with the same id.
+type arc struct {
+	id   int
+	edge *Edge
+	to   *Vertex
+}
+
+type traversals struct {
+	directed bool
+	vertices []*Vertex
+	arcs     map[*Vertex][]arc
+	in, out  map[*Vertex]int
+	count    int
+}
+
+// A MIXED graph is walked as directed or undirected when all its edges agree.
+func newTraversals(g *Graph) (*traversals, error) {
+	edges := g.AllEdges()
+	t := &traversals{
+		directed: g.Type() == DIRECTED,
+		vertices: g.Vertices(),
+		arcs:     make(map[*Vertex][]arc),
+		in:       make(map[*Vertex]int),
+		out:      make(map[*Vertex]int),
+	}
+	if g.Type() == MIXED && len(edges) > 0 {
+		t.directed = edges[0].directed
+		for _, e := range edges {
+			if e.directed != t.directed {
+				return nil, ErrMixedEdges
+			}
+		}
+	}
+	for _, e := range edges {
+		from, to := e.Ends()
+		t.add(e, from, to)
+	}
+	return t, nil
+}
+
+func (t *traversals) add(e *Edge, from, to *Vertex) {
+	id := t.count
+	t.count++
+	t.arcs[from] = append(t.arcs[from], arc{id, e, to})
+	t.out[from]++
+	t.in[to]++
+	if !t.directed && from != to {
+		t.arcs[to] = append(t.arcs[to], arc{id, e, from})
+		t.out[to]++
+		t.in[from]++
+	}
+}
+
+func (t *traversals) odd() []*Vertex {
+	out := make([]*Vertex, 0)
+	for _, v := range t.vertices {
+		if t.degree(v)%2 == 1 {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Undirected degree, a self-loop counts twice.
+func (t *traversals) degree(v *Vertex) int {
+	n := 0
+	for _, a := range t.arcs[v] {
+		n++
+		if a.to == v {
+			n++
+		}
+	}
+	return n
+}
+
+func (t *traversals) connected() bool {
+	var start *Vertex
+	for _, v := range t.vertices {
+		if len(t.arcs[v]) > 0 {
+			start = v
+			break
+		}
+	}
+	if start == nil {
+		return true
+	}
+	undirected := make(map[*Vertex][]*Vertex)
+	for v, arcs := range t.arcs {
+		for _, a := range arcs {
+			undirected[v] = append(undirected[v], a.to)
+			undirected[a.to] = append(undirected[a.to], v)
+		}
+	}
+	seen := map[*Vertex]bool{start: true}
+	stack := []*Vertex{start}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, u := range undirected[v] {
+			if !seen[u] {
+				seen[u] = true
+				stack = append(stack, u)
+			}
+		}
+	}
+	for v := range undirected {
+		if !seen[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// Start vertex of an Eulerian trail, nil when none exists.
+func (t *traversals) start(circuit bool) *Vertex {
+	if !t.connected() {
+		return nil
+	}
+	var first, start *Vertex
+	for _, v := range t.vertices {
+		if first == nil && len(t.arcs[v]) > 0 {
+			first = v
+		}
+	}
+	if t.directed {
+		plus, minus := 0, 0
+		for _, v := range t.vertices {
+			switch t.out[v] - t.in[v] {
+			case 0:
+			case 1:
+				plus++
+				start = v
+			case -1:
+				minus++
+			default:
+				return nil
+			}
+		}
+		if plus == 0 && minus == 0 {
+			return first
+		}
+		if circuit || plus != 1 || minus != 1 {
+			return nil
+		}
+		return start
+	}
+	odd := t.odd()
+	switch {
+	case len(odd) == 0:
+		return first
+	case len(odd) == 2 && !circuit:
+		return odd[0]
+	}
+	return nil
+}
+
+// Hierholzer's algorithm.
+func (t *traversals) walk(start *Vertex) *Path {
+	p := &Path{}
+	if start == nil {
+		return p
+	}
+	used := make([]bool, t.count)
+	next := make(map[*Vertex]int)
+	type step struct {
+		v *Vertex
+		e *Edge
+	}
+	stack := []step{{start, nil}}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		v := top.v
+		arcs := t.arcs[v]
+		for next[v] < len(arcs) && used[arcs[next[v]].id] {
+			next[v]++
+		}
+		if next[v] < len(arcs) {
+			a := arcs[next[v]]
+			used[a.id] = true
+			stack = append(stack, step{a.to, a.edge})
+			continue
+		}
+		stack = stack[:len(stack)-1]
+		p.Vertices = append(p.Vertices, v)
+		if top.e != nil {
+			p.Edges = append(p.Edges, top.e)
+		}
+	}
+	reverseVertices(p.Vertices)
+	reverseEdges(p.Edges)
+	return p
+}
+
+func (t *traversals) eulerian(circuit bool) (*Path, error) {
+	if t.count == 0 {
+		return &Path{}, nil
+	}
+	start := t.start(circuit)
+	if start == nil {
+		return nil, ErrNotEulerian
+	}
+	return t.walk(start), nil
+}
+
+func EulerianCircuit(g *Graph) (*Path, error) {
+	t, err := newTraversals(g)
+	if err != nil {
+		return nil, err
+	}
+	return t.eulerian(true)
+}
+
+func EulerianPath(g *Graph) (*Path, error) {
+	t, err := newTraversals(g)
+	if err != nil {
+		return nil, err
+	}
+	return t.eulerian(false)
+}
+
+func HasEulerianCircuit(g *Graph) bool {
+	t, err := newTraversals(g)
+	return err == nil && (t.count == 0 || t.start(true) != nil)
+}
+
+func HasEulerianPath(g *Graph) bool {
+	t, err := newTraversals(g)
+	return err == nil && (t.count == 0 || t.start(false) != nil)
+}
+
+// Closed walk over every edge at least once with minimum total weight.
+// Odd vertices are paired exactly up to 20 of them, greedily beyond that.
+func ChinesePostman(g *Graph, weight string) (*Path, error) {
+	if g.Type() != UNDIRECTED {
+		return nil, ErrNotUndirected
+	}
+	weights, err := nonNegativeWeights(g, weight)
+	if err != nil {
+		return nil, err
+	}
+	t, err := newTraversals(g)
+	if err != nil {
+		return nil, err
+	}
+	if !t.connected() {
+		return nil, ErrNotEulerian
+	}
+
+	w := func(e *Edge, from, to *Vertex) float64 {
+		return weights[e]
+	}
+	odd := t.odd()
+	paths := make([][]*Path, len(odd))
+	for i, v := range odd {
+		dist, prev := dijkstra(v, w, nil)
+		paths[i] = make([]*Path, len(odd))
+		for j, u := range odd {
+			paths[i][j] = treePath(v, u, dist, prev)
+		}
+	}
+	for _, pair := range pairOdd(paths) {
+		p := paths[pair[0]][pair[1]]
+		for i, e := range p.Edges {
+			t.add(e, p.Vertices[i], p.Vertices[i+1])
+		}
+	}
+
+	p, err := t.eulerian(true)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range p.Edges {
+		p.Weight += weights[e]
+	}
+	return p, nil
+}
+
+// Minimum weight perfect matching of odd vertices by their shortest paths.
+func pairOdd(paths [][]*Path) [][2]int {
+	n := len(paths)
+	if n == 0 {
+		return nil
+	}
+	pairs := make([][2]int, 0, n/2)
+	if n > 20 {
+		matched := make([]bool, n)
+		for i := 0; i < n; i++ {
+			if matched[i] {
+				continue
+			}
+			best := -1
+			for j := i + 1; j < n; j++ {
+				if !matched[j] && (best < 0 || paths[i][j].Weight < paths[i][best].Weight) {
+					best = j
+				}
+			}
+			matched[i], matched[best] = true, true
+			pairs = append(pairs, [2]int{i, best})
+		}
+		return pairs
+	}
+
+	full := 1<<uint(n) - 1
+	cost := make([]float64, full+1)
+	choice := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		cost[mask] = math.Inf(1)
+		if bits.OnesCount(uint(mask))%2 == 1 {
+			continue
+		}
+		i := bits.TrailingZeros(uint(mask))
+		for j := i + 1; j < n; j++ {
+			if mask&(1<<uint(j)) == 0 {
+				continue
+			}
+			rest := mask &^ (1<<uint(i) | 1<<uint(j))
+			if c := cost[rest] + paths[i][j].Weight; c < cost[mask] {
+				cost[mask] = c
+				choice[mask] = j
+			}
+		}
+	}
+	for mask := full; mask != 0; {
+		i := bits.TrailingZeros(uint(mask))
+		j := choice[mask]
+		pairs = append(pairs, [2]int{i, j})
+		mask &^= 1<<uint(i) | 1<<uint(j)
+	}
+	return pairs
+}
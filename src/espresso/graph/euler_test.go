@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"testing"
+)
+
+func testTrail(t *testing.T, name string, g *Graph, p *Path, closed bool) {
+	if p.Len() != g.EdgeCount() {
+		t.Errorf("(%s) Error trail should use every edge once (%d): %d", name, g.EdgeCount(), p.Len())
+	}
+	used := make(map[*Edge]bool)
+	for i, e := range p.Edges {
+		if used[e] {
+			t.Errorf("(%s) Error edge used twice: %s", name, p)
+		}
+		used[e] = true
+		if e.adjacent(p.Vertices[i]) != p.Vertices[i+1] {
+			t.Errorf("(%s) Error edge %d does not link %s to %s", name, i, p.Vertices[i].id, p.Vertices[i+1].id)
+		}
+	}
+	if closed && p.Source() != p.Target() {
+		t.Errorf("(%s) Error circuit should be closed: %s", name, p)
+	}
+}
+
+func TestEulerianUndirected(t *testing.T) {
+	g := NewUndirected()
+	g.Edge("1", "2")
+	g.Edge("2", "3")
+	g.Edge("3", "1")
+	g.Edge("3", "4")
+	g.Edge("4", "3")
+	g.Edge("4", "4")
+	g.Vertex("5")
+
+	p, err := EulerianCircuit(g)
+	if err != nil {
+		t.Fatalf("Error eulerian circuit: %v", err)
+	}
+	testTrail(t, "circuit", g, p, true)
+
+	g.Edge("1", "4")
+	if HasEulerianCircuit(g) {
+		t.Errorf("Error odd vertices should prevent circuit")
+	}
+	p, err = EulerianPath(g)
+	if err != nil {
+		t.Fatalf("Error eulerian path: %v", err)
+	}
+	testTrail(t, "path", g, p, false)
+	if p.Source().id != "1" || p.Target().id != "4" {
+		t.Errorf("Error path should go from 1 to 4: %s", p)
+	}
+
+	g.Edge("6", "7")
+	if HasEulerianPath(g) {
+		t.Errorf("Error disconnected edges should prevent path")
+	}
+}
+
+func TestEulerianDirected(t *testing.T) {
+	g := NewDirected()
+	g.Edge("a", "b")
+	g.Edge("b", "c")
+	g.Edge("c", "a")
+	g.Edge("a", "b")
+	g.Edge("b", "a")
+
+	p, err := EulerianCircuit(g)
+	if err != nil {
+		t.Fatalf("Error eulerian circuit: %v", err)
+	}
+	testTrail(t, "directed circuit", g, p, true)
+
+	g.Edge("c", "d")
+	if _, err := EulerianCircuit(g); err != ErrNotEulerian {
+		t.Errorf("Error unbalanced circuit: %v", err)
+	}
+	p, err = EulerianPath(g)
+	if err != nil {
+		t.Fatalf("Error eulerian path: %v", err)
+	}
+	testTrail(t, "directed path", g, p, false)
+	if p.Source().id != "c" || p.Target().id != "d" {
+		t.Errorf("Error path should go from c to d: %s", p)
+	}
+}
+
+func TestEulerianMixed(t *testing.T) {
+	g := NewMixed()
+	g.Edge("a", "b")
+	g.Edge("a", "b")
+	if _, err := EulerianCircuit(g); err != ErrNotEulerian {
+		t.Errorf("Error mixed directed circuit: %v", err)
+	}
+	if p, err := EulerianPath(g); err == nil || HasEulerianPath(g) {
+		t.Errorf("Error mixed directed path: %s", p)
+	}
+
+	u := NewMixed()
+	u.UndirectedEdge("a", "b")
+	u.UndirectedEdge("b", "a")
+	p, err := EulerianCircuit(u)
+	if err != nil {
+		t.Fatalf("Error mixed undirected circuit: %v", err)
+	}
+	testTrail(t, "mixed undirected circuit", u, p, true)
+
+	u.Edge("b", "c")
+	if _, err := EulerianPath(u); err != ErrMixedEdges || HasEulerianPath(u) {
+		t.Errorf("Error mixed edges path: %v", err)
+	}
+}
+
+func TestChinesePostman(t *testing.T) {
+	g := NewUndirected()
+	g.Edge("a", "b").Set("m", 3)
+	g.Edge("b", "c").Set("m", 2)
+	g.Edge("c", "d").Set("m", 4)
+	g.Edge("d", "a").Set("m", 1)
+	g.Edge("a", "c").Set("m", 10)
+
+	p, err := ChinesePostman(g, "m")
+	if err != nil {
+		t.Fatalf("Error chinese postman: %v", err)
+	}
+	// odd a and c are paired through b (5), not d (5) or the direct edge (10)
+	if p.Weight != 25 {
+		t.Errorf("Error postman weight (25): %g %s", p.Weight, p)
+	}
+	if p.Source() != p.Target() {
+		t.Errorf("Error postman route should be closed: %s", p)
+	}
+	covered := make(map[*Edge]bool)
+	for _, e := range p.Edges {
+		covered[e] = true
+	}
+	if len(covered) != g.EdgeCount() {
+		t.Errorf("Error postman should cover every edge: %d", len(covered))
+	}
+
+	if _, err := ChinesePostman(NewDirected(), ""); err != ErrNotUndirected {
+		t.Errorf("Error directed postman: %v", err)
+	}
+}
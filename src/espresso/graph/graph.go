@@ -2,7 +2,10 @@ package graph
 
 import (
 	"container/list"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -11,10 +14,20 @@ type GraphType string
 const (
 	DIRECTED   GraphType = "DIRECTED"
 	UNDIRECTED           = "UNDIRECTED"
+	MIXED                = "MIXED"
+)
+
+var (
+	ErrHyperEdgeSize  = errors.New("hyperedge needs at least two distinct vertices")
+	ErrHyperEdgeGraph = errors.New("hyperedges need a MIXED graph")
+	ErrEdgeIdTaken    = errors.New("edge id is taken by another edge")
 )
 
 type data struct {
-	values map[string]interface{}
+	values   map[string]interface{}
+	versions map[string][]Version
+	temporal *temporal
+	doc      *document
 }
 
 func (d *data) string(sep string) string {
@@ -42,7 +55,13 @@ func (d *data) Set(key string, value interface{}) *data {
 	if d.values == nil {
 		d.values = make(map[string]interface{})
 	}
+	if d.temporal != nil {
+		d.record(key, value, true)
+	}
 	d.values[key] = value
+	if d.doc != nil {
+		d.doc.set(key, value)
+	}
 	return d
 }
 
@@ -61,10 +80,25 @@ func (d *data) Get(key string) (interface{}, bool) {
 	return v, ok
 }
 
+func (d *data) Keys() []string {
+	keys := make([]string, 0, len(d.values))
+	for k := range d.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (d *data) Unset(key string) {
 	if d.values == nil {
 		return
 	}
+	if _, ok := d.values[key]; ok && d.temporal != nil {
+		d.record(key, nil, false)
+	}
+	if d.doc != nil {
+		d.doc.unset(key)
+	}
 	delete(d.values, key)
 }
 
@@ -72,20 +106,34 @@ type Vertex struct {
 	id, label string
 	graph     *Graph
 	edges     *list.List
+	valid     Interval
 	data
 }
 
+// Hyperedges have no link, their vertices are kept in members. The link
+// starts at from, kept so edges archived by a temporal graph still resolve
+// their vertices once those leave the graph.
 type Edge struct {
-	label string
-	graph *Graph
-	link  map[string]*Vertex
+	id       string
+	label    string
+	graph    *Graph
+	from     *Vertex
+	link     map[string]*Vertex
+	directed bool
+	members  []*Vertex
+	valid    Interval
 	data
 }
 
 type Graph struct {
-	_type    GraphType
-	vertices map[string]*Vertex
-	edges    int
+	_type      GraphType
+	vertices   map[string]*Vertex
+	edges      int
+	hyperedges int
+	edgeIds    map[string]*Edge
+	nextEdge   int
+	temporal   *temporal
+	index      *TextIndex
 	data
 }
 
@@ -101,6 +149,11 @@ func NewUndirected() *Graph {
 	return &Graph{_type: UNDIRECTED, edges: 0}
 }
 
+// Edges are directed unless created with UndirectedEdge.
+func NewMixed() *Graph {
+	return &Graph{_type: MIXED, edges: 0}
+}
+
 func (g *Graph) Type() GraphType {
 	return g._type
 }
@@ -109,10 +162,15 @@ func (g *Graph) VertexCount() int {
 	return len(g.vertices)
 }
 
+// Hyperedges are counted apart, binary edge algorithms leave them out.
 func (g *Graph) EdgeCount() int {
 	return g.edges
 }
 
+func (g *Graph) HyperEdgeCount() int {
+	return g.hyperedges
+}
+
 func (v *Vertex) String() string {
 	out := v.id
 	if v.label != "" {
@@ -144,14 +202,18 @@ func (e *Edge) String() string {
 }
 
 func (g *Graph) string(v1, v2 *Vertex, e *Edge) string {
-	switch g.Type() {
-	case DIRECTED:
+	if e.directed {
 		return fmt.Sprintf("(%s)-%s->(%s)\n", v1, e, v2)
-	case UNDIRECTED:
-		return fmt.Sprintf("(%s)-%s-(%s)\n", v1, e, v2)
-	default:
-		return "<unknown>"
 	}
+	return fmt.Sprintf("(%s)-%s-(%s)\n", v1, e, v2)
+}
+
+func (g *Graph) hyperString(e *Edge) string {
+	members := make([]string, len(e.members))
+	for i, v := range e.members {
+		members[i] = fmt.Sprintf("(%s)", v)
+	}
+	return fmt.Sprintf("{%s}-%s\n", strings.Join(members, ","), e)
 }
 
 func (g *Graph) String() string {
@@ -173,6 +235,12 @@ func (g *Graph) String() string {
 				continue
 			}
 
+			if e.members != nil {
+				out += g.hyperString(e)
+				edges[e] = true
+				continue
+			}
+
 			adj, ok := e.link[v.id]
 			if !ok {
 				continue
@@ -214,11 +282,23 @@ func (g *Graph) Vertex(id string) *Vertex {
 	if ok {
 		return v
 	}
-	v = &Vertex{
+	v = g.newVertex(id)
+	g.addVertex(v)
+	return v
+}
+
+func (g *Graph) newVertex(id string) *Vertex {
+	v := &Vertex{
 		id:    id,
 		graph: g,
 	}
-	g.addVertex(v)
+	if g.temporal != nil {
+		v.valid.From = g.temporal.now()
+		v.temporal = g.temporal
+	}
+	if g.index != nil {
+		g.index.attach(&v.data, v, nil)
+	}
 	return v
 }
 
@@ -227,6 +307,10 @@ func (v *Vertex) Label(label string) *Vertex {
 	return v
 }
 
+func (v *Vertex) GetLabel() string {
+	return v.label
+}
+
 func (v *Vertex) Id() string {
 	return v.id
 }
@@ -264,46 +348,197 @@ func (v *Vertex) unbind(e *Edge) {
 	}
 }
 
-func (g *Graph) edge(v1, v2 *Vertex) *Edge {
-	switch g.Type() {
-	case DIRECTED:
+func (g *Graph) edge(v1, v2 *Vertex, directed bool) *Edge {
+	if directed {
 		return &Edge{
-			graph: g,
-			link:  map[string]*Vertex{v1.id: v2},
+			graph:    g,
+			from:     v1,
+			link:     map[string]*Vertex{v1.id: v2},
+			directed: true,
 		}
-	case UNDIRECTED:
-		return &Edge{
-			graph: g,
-			link: map[string]*Vertex{
-				v1.id: v2,
-				v2.id: v1,
-			},
-		}
-	default:
-		return nil
+	}
+	return &Edge{
+		graph: g,
+		from:  v1,
+		link: map[string]*Vertex{
+			v1.id: v2,
+			v2.id: v1,
+		},
 	}
 }
 
 func (g *Graph) Edge(id1, id2 string) *Edge {
-	v1 := g.Vertex(id1)
-	v2 := g.Vertex(id2)
+	return g.link(g.Vertex(id1), g.Vertex(id2))
+}
+
+// Two-way edge in MIXED and UNDIRECTED graphs, nil in a DIRECTED graph.
+func (g *Graph) UndirectedEdge(id1, id2 string) *Edge {
+	if g.Type() == DIRECTED {
+		return nil
+	}
+	return g.add(g.edge(g.Vertex(id1), g.Vertex(id2), false))
+}
+
+// Edge connecting two or more distinct vertices, without direction; only a
+// MIXED graph has them.
+func (g *Graph) HyperEdge(ids ...string) (*Edge, error) {
+	e, err := g.hyperEdge(ids)
+	if err != nil {
+		return nil, err
+	}
+	return g.add(e), nil
+}
+
+func (g *Graph) hyperEdge(ids []string) (*Edge, error) {
+	if g.Type() != MIXED {
+		return nil, ErrHyperEdgeGraph
+	}
+	distinct := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			distinct = append(distinct, id)
+		}
+	}
+	if len(distinct) < 2 {
+		return nil, ErrHyperEdgeSize
+	}
+	e := &Edge{graph: g, members: make([]*Vertex, len(distinct))}
+	for i, id := range distinct {
+		e.members[i] = g.Vertex(id)
+	}
+	return e, nil
+}
+
+func (g *Graph) link(v1, v2 *Vertex) *Edge {
+	return g.add(g.edge(v1, v2, g.Type() != UNDIRECTED))
+}
 
-	e := g.edge(v1, v2)
+func (g *Graph) add(e *Edge) *Edge {
+	if g.edgeIds == nil {
+		g.edgeIds = make(map[string]*Edge)
+	}
+	if _, taken := g.edgeIds[e.id]; taken || e.id == "" {
+		e.id = g.newEdgeId()
+	}
+	g.edgeIds[e.id] = e
+	if g.temporal != nil {
+		e.valid.From = g.temporal.now()
+		e.temporal = g.temporal
+	}
+	if g.index != nil {
+		g.index.attach(&e.data, nil, e)
+	}
 
-	v1.bind(e)
-	if v2 != v1 {
-		v2.bind(e)
+	for _, v := range e.Members() {
+		v.bind(e)
 	}
 
-	g.edges++
+	if e.IsHyperedge() {
+		g.hyperedges++
+	} else {
+		g.edges++
+	}
 	return e
 }
 
+// Generated ids are "e" and a counter, skipping ids given by callers and ids
+// of removed edges.
+func (g *Graph) newEdgeId() string {
+	for {
+		g.nextEdge++
+		id := "e" + strconv.Itoa(g.nextEdge)
+		if _, taken := g.edgeIds[id]; !taken {
+			return id
+		}
+	}
+}
+
+// Edge with a caller supplied id; an existing edge with that id is returned
+// when it joins the same vertices, ids of removed edges are not given again.
+func (g *Graph) EdgeWithId(id, from, to string) (*Edge, error) {
+	if e, taken := g.edgeIds[id]; taken {
+		if e == nil || !e.joins(from, to) {
+			return nil, ErrEdgeIdTaken
+		}
+		return e, nil
+	}
+	e := g.edge(g.Vertex(from), g.Vertex(to), g.Type() != UNDIRECTED)
+	e.id = id
+	return g.add(e), nil
+}
+
+func (e *Edge) joins(from, to string) bool {
+	if e.IsHyperedge() {
+		return false
+	}
+	u, v := e.Ends()
+	return u.id == from && v.id == to || !e.directed && u.id == to && v.id == from
+}
+
+func (g *Graph) EdgeById(id string) *Edge {
+	return g.edgeIds[id]
+}
+
+// Gives e another id unless it is empty or taken.
+func (g *Graph) setEdgeId(e *Edge, id string) {
+	if _, taken := g.edgeIds[id]; taken || id == "" {
+		return
+	}
+	delete(g.edgeIds, e.id)
+	e.id = id
+	g.edgeIds[id] = e
+}
+
+func (e *Edge) Id() string {
+	return e.id
+}
+
 func (e *Edge) Label(label string) *Edge {
 	e.label = label
 	return e
 }
 
+func (e *Edge) GetLabel() string {
+	return e.label
+}
+
+func (e *Edge) IsDirected() bool {
+	return e.directed
+}
+
+func (e *Edge) IsHyperedge() bool {
+	return e.members != nil
+}
+
+// Distinct vertices of the edge, source first when directed.
+func (e *Edge) Members() []*Vertex {
+	if e.members != nil {
+		return append([]*Vertex{}, e.members...)
+	}
+	for k, v := range e.link {
+		u := e.vertex(k)
+		if u == v {
+			return []*Vertex{u}
+		}
+		if !e.directed && v.id < u.id {
+			u, v = v, u
+		}
+		return []*Vertex{u, v}
+	}
+	return nil
+}
+
+func (e *Edge) hasMember(v *Vertex) bool {
+	for _, u := range e.members {
+		if u == v {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *Graph) Edges(id1, id2 string) []*Edge {
 	v1, ok1 := g.getVertex(id1)
 	v2, ok2 := g.getVertex(id2)
@@ -323,6 +558,8 @@ func (g *Graph) Edges(id1, id2 string) []*Edge {
 		e := i.Value.(*Edge)
 		if adj, ok := e.link[v1.id]; ok && adj == v2 {
 			edges = append(edges, e)
+		} else if v1 != v2 && e.hasMember(v2) {
+			edges = append(edges, e)
 		}
 	}
 
@@ -347,20 +584,43 @@ func (v *Vertex) Remove() {
 	}
 
 	delete(v.graph.vertices, v.id)
+	if v.doc != nil {
+		v.doc.remove()
+		v.doc = nil
+	}
+	if t := v.graph.temporal; t != nil {
+		t.archive(&v.valid, &v.data)
+		t.vertices = append(t.vertices, v)
+	}
 	v.graph = nil
 	v.data.values = nil
 }
 
 func (e *Edge) Remove() {
-	e.graph.edges--
-	for k, v := range e.link {
-		vk := e.graph.Vertex(k)
-		vk.unbind(e)
-		if v != vk {
-			v.unbind(e)
-		}
+	if e.IsHyperedge() {
+		e.graph.hyperedges--
+	} else {
+		e.graph.edges--
+	}
+	// the id stays reserved so it is never shared across snapshots
+	e.graph.edgeIds[e.id] = nil
+	if e.doc != nil {
+		e.doc.remove()
+		e.doc = nil
+	}
+	for _, v := range e.Members() {
+		v.unbind(e)
+	}
+	if t := e.graph.temporal; t != nil {
+		t.archive(&e.valid, &e.data)
+		t.edges = append(t.edges, e)
+		e.graph = nil
+		e.data.values = nil
+		return
 	}
+	e.from = nil
 	e.link = nil
+	e.members = nil
 	e.graph = nil
 	e.data.values = nil
 }
@@ -2,6 +2,7 @@ package graph
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -208,6 +209,10 @@ func testData(t *testing.T, d *data) {
 		t.Errorf("Error setting data: %v", d)
 	}
 
+	if keys := d.Keys(); !reflect.DeepEqual(keys, []string{k3, k2, k4, k1}) {
+		t.Errorf("Error data keys should be sorted: %v", keys)
+	}
+
 	test(k1, func(v interface{}) bool { return v == v1 })
 	test(k2, func(v interface{}) bool { return v == v2 })
 	test(k3, func(v interface{}) bool { return v == v3 })
@@ -292,3 +297,114 @@ func TestData(t *testing.T) {
 	e := g.Edge("1", "2")
 	testData(t, &e.data)
 }
+
+func TestMixedGraph(t *testing.T) {
+	g := NewMixed()
+	d := g.Edge("1", "2")
+	u := g.UndirectedEdge("2", "3")
+
+	if g.Type() != MIXED || !d.IsDirected() || u.IsDirected() {
+		t.Errorf("Error mixed edges: %s %v %v", g.Type(), d.IsDirected(), u.IsDirected())
+	}
+	if n := g.Edges("2", "1"); n != nil {
+		t.Errorf("Error no edge from 2 to 1: %+v", n)
+	}
+	if n := g.Edges("3", "2"); len(n) != 1 || n[0] != u {
+		t.Errorf("Error undirected edge both ways: %+v", n)
+	}
+	if out := g.String(); !strings.Contains(out, "(1)-->(2)") || !strings.Contains(out, "(2)--(3)") && !strings.Contains(out, "(3)--(2)") {
+		t.Errorf("Error mixed graph string: %s", out)
+	}
+	if NewDirected().UndirectedEdge("1", "2") != nil {
+		t.Errorf("Error directed graph should not create undirected edges")
+	}
+
+	u.Remove()
+	if g.EdgeCount() != 1 || g.Vertex("3").EdgeCount() != 0 || g.Vertex("2").EdgeCount() != 1 {
+		t.Errorf("Error removing undirected edge: %d", g.EdgeCount())
+	}
+}
+
+func TestHyperEdge(t *testing.T) {
+	g := NewMixed()
+	g.Edge("a", "b")
+	h, err := g.HyperEdge("a", "b", "c", "a")
+	if err != nil {
+		t.Fatalf("Error creating hyperedge: %v", err)
+	}
+	h.Label("paper").Set("year", 2010)
+
+	if !h.IsHyperedge() || len(h.Members()) != 3 || g.EdgeCount() != 1 || g.HyperEdgeCount() != 1 {
+		t.Errorf("Error hyperedge: %v %d %d", h.Members(), g.EdgeCount(), g.HyperEdgeCount())
+	}
+	if n := g.Edges("c", "a"); len(n) != 1 || n[0] != h {
+		t.Errorf("Error hyperedge lookup: %+v", n)
+	}
+	if n := g.Edges("a", "b"); len(n) != 2 {
+		t.Errorf("Error edges with hyperedge (2): %+v", n)
+	}
+	if n := g.Edges("c", "c"); n != nil {
+		t.Errorf("Error hyperedge is no self loop: %+v", n)
+	}
+	if out := g.String(); !strings.Contains(out, "{(a),(b),(c)}-[:paper {year:2010}]") {
+		t.Errorf("Error hyperedge string: %s", out)
+	}
+	if all := g.AllEdges(); len(all) != 1 {
+		t.Errorf("Error hyperedges are not binary edges: %v", all)
+	}
+
+	for _, ids := range [][]string{{}, {"d"}, {"d", "d"}} {
+		if e, err := g.HyperEdge(ids...); err != ErrHyperEdgeSize || e != nil {
+			t.Errorf("Error hyperedge of %v: %v", ids, err)
+		}
+	}
+	for _, u := range []*Graph{NewDirected(), NewUndirected()} {
+		if e, err := u.HyperEdge("a", "b"); err != ErrHyperEdgeGraph || e != nil || u.VertexCount() != 0 {
+			t.Errorf("Error hyperedge on %s graph: %v", u.Type(), err)
+		}
+	}
+	if g.EdgeCount() != 1 || g.HyperEdgeCount() != 1 || g.HasVertex("d") {
+		t.Errorf("Error rejected hyperedges should not change the graph: %d", g.EdgeCount())
+	}
+
+	g.Vertex("b").Remove()
+	if g.EdgeCount() != 0 || g.HyperEdgeCount() != 0 || g.Vertex("c").EdgeCount() != 0 || len(g.HyperEdges()) != 0 {
+		t.Errorf("Error removing vertex of hyperedge: %d", g.EdgeCount())
+	}
+}
+
+func TestEdgeIds(t *testing.T) {
+	g := NewMixed()
+	e1 := g.Edge("2", "3")
+	g.EdgeWithId("e2", "1", "2")
+	e2 := g.Edge("2", "3")
+	e3, _ := g.EdgeWithId("x", "3", "1")
+
+	if e1.Id() != "e1" || e2.Id() != "e3" || e3.Id() != "x" {
+		t.Errorf("Error edge ids (e1, e3, x): %s %s %s", e1.Id(), e2.Id(), e3.Id())
+	}
+	if g.EdgeById("e3") != e2 || g.EdgeById("x") != e3 || g.EdgeById("y") != nil {
+		t.Errorf("Error edge by id")
+	}
+	if e, err := g.EdgeWithId("x", "3", "1"); e != e3 || err != nil || g.EdgeCount() != 4 {
+		t.Errorf("Error existing edge id should be returned: %v %d", err, g.EdgeCount())
+	}
+	if e, err := g.EdgeWithId("x", "1", "3"); e != nil || err != ErrEdgeIdTaken {
+		t.Errorf("Error existing edge id with other ends: %v", err)
+	}
+	if h, _ := g.HyperEdge("1", "2", "3"); h.Id() != "e4" || g.EdgeById("e4") != h {
+		t.Errorf("Error hyperedge id: %s", h.Id())
+	}
+
+	g.Vertex("2").Remove()
+	if g.EdgeById("e1") != nil || g.EdgeById("e4") != nil || g.EdgeById("x") != e3 {
+		t.Errorf("Error edge ids after removing vertex")
+	}
+	if e, err := g.EdgeWithId("e1", "1", "3"); e != nil || err != ErrEdgeIdTaken {
+		t.Errorf("Error id of removed edge should stay reserved: %v", err)
+	}
+	g.nextEdge = 0
+	if e := g.Edge("1", "3"); e.Id() != "e5" {
+		t.Errorf("Error generated id should skip removed ids (e5): %s", e.Id())
+	}
+}
@@ -0,0 +1,330 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type ImportError struct {
+	Line   int
+	Column string
+	Err    error
+}
+
+func (e *ImportError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("line %d, column %s: %v", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
+
+// Builds a graph from neo4j-admin import style CSV, edge lists and adjacency lists.
+// Bulk presizes vertex and edge id storage with SizeHint, looks vertices up
+// once and adopts parsed property maps without copying. A graph with
+// versioning or a text index is loaded the regular way.
+type Importer struct {
+	Graph      *Graph
+	Delimiter  rune
+	ArrayDelim string
+	Bulk       bool
+	SizeHint   int
+	Columns    []string
+}
+
+func NewImporter(g *Graph) *Importer {
+	return &Importer{
+		Graph:      g,
+		Delimiter:  ',',
+		ArrayDelim: ";",
+		Columns:    []string{"weight:float"},
+	}
+}
+
+type column struct {
+	name, kind string
+	array      bool
+	role       string
+}
+
+func parseHeader(fields []string) ([]column, error) {
+	columns := make([]column, len(fields))
+	for i, field := range fields {
+		name, kind := field, ""
+		if j := strings.LastIndex(field, ":"); j >= 0 {
+			name, kind = field[:j], field[j+1:]
+		}
+		if j := strings.Index(kind, "("); j >= 0 {
+			kind = kind[:j]
+		}
+		c := column{name: name}
+		switch kind {
+		case "ID", "START_ID", "END_ID", "LABEL", "TYPE", "IGNORE":
+			c.role = kind
+		case "":
+			c.kind = "string"
+		default:
+			c.kind = strings.ToLower(kind)
+			if strings.HasSuffix(c.kind, "[]") {
+				c.array = true
+				c.kind = strings.TrimSuffix(c.kind, "[]")
+			}
+			switch c.kind {
+			case "string", "int", "long", "short", "byte", "float", "double", "boolean", "date", "datetime", "localdatetime":
+			default:
+				return nil, fmt.Errorf("unknown column type: %s", field)
+			}
+		}
+		columns[i] = c
+	}
+	return columns, nil
+}
+
+func (c *column) parse(s, delim string) (interface{}, error) {
+	if !c.array {
+		return c.value(s)
+	}
+	parts := strings.Split(s, delim)
+	values := make([]interface{}, len(parts))
+	for i, p := range parts {
+		v, err := c.value(p)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (c *column) value(s string) (interface{}, error) {
+	switch c.kind {
+	case "int", "long", "short", "byte":
+		return strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	case "float", "double":
+		return strconv.ParseFloat(strings.TrimSpace(s), 64)
+	case "boolean":
+		return strconv.ParseBool(strings.TrimSpace(s))
+	case "date", "datetime", "localdatetime":
+		return toTime(c.name, s)
+	}
+	return s, nil
+}
+
+func (im *Importer) bulk() bool {
+	return im.Bulk && im.Graph.temporal == nil && im.Graph.index == nil
+}
+
+func (im *Importer) vertex(id string) *Vertex {
+	if !im.bulk() {
+		return im.Graph.Vertex(id)
+	}
+	g := im.Graph
+	if g.vertices == nil {
+		g.vertices = make(map[string]*Vertex, im.SizeHint)
+	}
+	if v, ok := g.vertices[id]; ok {
+		return v
+	}
+	v := g.newVertex(id)
+	g.vertices[id] = v
+	return v
+}
+
+func (im *Importer) edge(from, to string) *Edge {
+	if !im.bulk() {
+		return im.Graph.Edge(from, to)
+	}
+	g := im.Graph
+	if g.edgeIds == nil {
+		g.edgeIds = make(map[string]*Edge, im.SizeHint)
+	}
+	return g.add(g.edge(im.vertex(from), im.vertex(to), g.Type() != UNDIRECTED))
+}
+
+func (im *Importer) reader(r io.Reader) *csv.Reader {
+	c := csv.NewReader(r)
+	c.Comma = im.Delimiter
+	c.ReuseRecord = true
+	return c
+}
+
+func (im *Importer) records(r io.Reader, row func(columns []column, record []string, line int) error) error {
+	c := im.reader(r)
+	header, err := c.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return &ImportError{Line: 1, Err: err}
+	}
+	columns, err := parseHeader(header)
+	if err != nil {
+		return &ImportError{Line: 1, Err: err}
+	}
+	for {
+		record, err := c.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			var pe *csv.ParseError
+			if errors.As(err, &pe) {
+				return &ImportError{Line: pe.Line, Err: pe.Err}
+			}
+			return err
+		}
+		line, _ := c.FieldPos(0)
+		if err := row(columns, record, line); err != nil {
+			return err
+		}
+	}
+}
+
+// Properties from typed columns; empty fields are left unset.
+func (im *Importer) properties(columns []column, record []string, line int) (map[string]interface{}, error) {
+	var values map[string]interface{}
+	for i, c := range columns {
+		if c.role != "" && c.role != "ID" || c.name == "" || record[i] == "" {
+			continue
+		}
+		if c.role == "ID" {
+			c.kind = "string"
+		}
+		v, err := c.parse(record[i], im.ArrayDelim)
+		if err != nil {
+			return nil, &ImportError{Line: line, Column: c.name, Err: err}
+		}
+		if values == nil {
+			values = make(map[string]interface{}, len(columns))
+		}
+		values[c.name] = v
+	}
+	return values, nil
+}
+
+func (im *Importer) field(columns []column, record []string, role string) (string, bool) {
+	for i, c := range columns {
+		if c.role == role {
+			return record[i], true
+		}
+	}
+	return "", false
+}
+
+// Header like id:ID,:LABEL,name,born:int; labels separated by ';' are kept as one label.
+func (im *Importer) Nodes(r io.Reader) error {
+	return im.records(r, func(columns []column, record []string, line int) error {
+		id, ok := im.field(columns, record, "ID")
+		if !ok || id == "" {
+			return &ImportError{Line: line, Err: errors.New("missing :ID")}
+		}
+		values, err := im.properties(columns, record, line)
+		if err != nil {
+			return err
+		}
+		v := im.vertex(id)
+		if label, ok := im.field(columns, record, "LABEL"); ok && label != "" {
+			v.Label(label)
+		}
+		if values != nil {
+			im.setValues(&v.data, values)
+		}
+		return nil
+	})
+}
+
+// Header like :START_ID,:END_ID,:TYPE,role.
+func (im *Importer) Relationships(r io.Reader) error {
+	return im.records(r, func(columns []column, record []string, line int) error {
+		from, ok1 := im.field(columns, record, "START_ID")
+		to, ok2 := im.field(columns, record, "END_ID")
+		if !ok1 || !ok2 || from == "" || to == "" {
+			return &ImportError{Line: line, Err: errors.New("missing :START_ID or :END_ID")}
+		}
+		values, err := im.properties(columns, record, line)
+		if err != nil {
+			return err
+		}
+		e := im.edge(from, to)
+		if label, ok := im.field(columns, record, "TYPE"); ok {
+			e.Label(label)
+		}
+		if values != nil {
+			im.setValues(&e.data, values)
+		}
+		return nil
+	})
+}
+
+func (im *Importer) setValues(d *data, values map[string]interface{}) {
+	if im.bulk() && d.values == nil {
+		d.values = values
+		return
+	}
+	d.SetMap(values)
+}
+
+func (im *Importer) lines(r io.Reader, line func(fields []string, n int) error) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	n := 0
+	for s.Scan() {
+		n++
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "%") {
+			continue
+		}
+		if err := line(strings.Fields(text), n); err != nil {
+			return err
+		}
+	}
+	if err := s.Err(); err != nil {
+		return &ImportError{Line: n + 1, Err: err}
+	}
+	return nil
+}
+
+// Whitespace separated "from to [extra...]", extra fields typed by Columns.
+func (im *Importer) EdgeList(r io.Reader) error {
+	columns, err := parseHeader(im.Columns)
+	if err != nil {
+		return err
+	}
+	return im.lines(r, func(fields []string, n int) error {
+		if len(fields) < 2 {
+			return &ImportError{Line: n, Err: errors.New("edge needs two vertices")}
+		}
+		extra := fields[2:]
+		if len(extra) > len(columns) {
+			return &ImportError{Line: n, Err: fmt.Errorf("expected at most %d fields, got %d", len(columns)+2, len(fields))}
+		}
+		values, err := im.properties(columns[:len(extra)], extra, n)
+		if err != nil {
+			return err
+		}
+		e := im.edge(fields[0], fields[1])
+		if values != nil {
+			im.setValues(&e.data, values)
+		}
+		return nil
+	})
+}
+
+// Whitespace separated "vertex neighbor...", a vertex alone on a line has no edges.
+func (im *Importer) AdjacencyList(r io.Reader) error {
+	return im.lines(r, func(fields []string, n int) error {
+		v := im.vertex(fields[0])
+		for _, u := range fields[1:] {
+			im.edge(v.id, u)
+		}
+		return nil
+	})
+}
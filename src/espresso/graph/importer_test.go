@@ -0,0 +1,157 @@
+package graph
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	movies = `id:ID,:LABEL,title,year:date,rating:float,tags:string[]
+0,Movie,The Matrix,1999-03-31,8.7,action;scifi
+1,Movie,The Matrix Reloaded,2003-05-07,,
+3,Actor,,,,
+`
+	actsIn = `:START_ID,:END_ID,:TYPE,role,order:int
+3,0,ACTS_IN,Neo,1
+3,1,ACTS_IN,Neo,1
+3,1,ACTS_IN,"Neo, again",2
+`
+)
+
+func testImported(t *testing.T, g *Graph) {
+	if g.VertexCount() != 3 || g.EdgeCount() != 3 {
+		t.Fatalf("Error imported graph size (3, 3): %d, %d", g.VertexCount(), g.EdgeCount())
+	}
+	v := g.Vertex("0")
+	if v.label != "Movie" {
+		t.Errorf("Error imported label: %v", v)
+	}
+	if year, err := Prop[time.Time](v, "year"); err != nil || year.Year() != 1999 {
+		t.Errorf("Error imported date: %v, %v", year, err)
+	}
+	if rating, _ := v.Get("rating"); rating != 8.7 {
+		t.Errorf("Error imported float: %#v", rating)
+	}
+	if tags, _ := v.Get("tags"); len(tags.([]interface{})) != 2 {
+		t.Errorf("Error imported array: %#v", tags)
+	}
+	if id, _ := v.GetString("id"); id != "0" {
+		t.Errorf("Error imported id property: %s", id)
+	}
+	if _, ok := g.Vertex("1").Get("rating"); ok {
+		t.Errorf("Error empty field should be unset")
+	}
+	edges := g.Edges("3", "1")
+	if len(edges) != 2 || edges[0].label != "ACTS_IN" {
+		t.Fatalf("Error imported parallel edges: %v", edges)
+	}
+	if order, _ := edges[1].Get("order"); order != int64(2) {
+		t.Errorf("Error imported int: %#v", order)
+	}
+	if role, _ := edges[1].GetString("role"); role != "Neo, again" {
+		t.Errorf("Error imported quoted field: %s", role)
+	}
+}
+
+func TestImporterCSV(t *testing.T) {
+	for _, bulk := range []bool{false, true} {
+		g := New()
+		im := NewImporter(g)
+		im.Bulk = bulk
+		im.SizeHint = 3
+		if err := im.Nodes(strings.NewReader(movies)); err != nil {
+			t.Fatalf("Error importing nodes (bulk %t): %v", bulk, err)
+		}
+		if err := im.Relationships(strings.NewReader(actsIn)); err != nil {
+			t.Fatalf("Error importing relationships (bulk %t): %v", bulk, err)
+		}
+		testImported(t, g)
+	}
+}
+
+func TestImporterErrors(t *testing.T) {
+	im := NewImporter(New())
+
+	err := im.Nodes(strings.NewReader("id:ID,born:int\n1,1964\n2,unknown\n"))
+	var ie *ImportError
+	if !errors.As(err, &ie) || ie.Line != 3 || ie.Column != "born" {
+		t.Errorf("Error invalid int should report line 3, column born: %v", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Error should wrap the conversion error: %v", err)
+	}
+
+	err = im.Relationships(strings.NewReader(":START_ID,:END_ID\n1,2\n\n3\n"))
+	if !errors.As(err, &ie) || ie.Line != 4 {
+		t.Errorf("Error wrong field count should report line 4: %v", err)
+	}
+
+	if err := im.Nodes(strings.NewReader("id:ID,x:money\n")); err == nil {
+		t.Errorf("Error unknown column type should fail")
+	}
+
+	err = im.EdgeList(strings.NewReader("# comment\n1 2\n1\n"))
+	if !errors.As(err, &ie) || ie.Line != 3 {
+		t.Errorf("Error short edge should report line 3: %v", err)
+	}
+}
+
+func TestImporterLists(t *testing.T) {
+	g := NewUndirected()
+	im := NewImporter(g)
+	im.Bulk = true
+
+	err := im.EdgeList(strings.NewReader("# roads\n1 2 3.5\n2\t3\n\n3 1 1\n"))
+	if err != nil {
+		t.Fatalf("Error importing edge list: %v", err)
+	}
+	if g.EdgeCount() != 3 {
+		t.Errorf("Error edge list edges (3): %d", g.EdgeCount())
+	}
+	if w, _ := g.Edges("2", "1")[0].GetFloat("weight"); w != 3.5 {
+		t.Errorf("Error edge list weight (3.5): %g", w)
+	}
+	if _, ok := g.Edges("2", "3")[0].Get("weight"); ok {
+		t.Errorf("Error edge list missing weight should be unset")
+	}
+
+	a := NewDirected()
+	if err := NewImporter(a).AdjacencyList(strings.NewReader("a b c\nb c\nd\n")); err != nil {
+		t.Fatalf("Error importing adjacency list: %v", err)
+	}
+	if a.VertexCount() != 4 || a.EdgeCount() != 3 || len(a.Edges("a", "c")) != 1 {
+		t.Errorf("Error adjacency list (4, 3): %d, %d", a.VertexCount(), a.EdgeCount())
+	}
+}
+
+func BenchmarkImporter(b *testing.B) {
+	var nodes, rels strings.Builder
+	nodes.WriteString("id:ID,:LABEL,name,born:int\n")
+	rels.WriteString(":START_ID,:END_ID,:TYPE,since:int\n")
+	const n = 10000
+	for i := 0; i < n; i++ {
+		nodes.WriteString(strconv.Itoa(i) + ",Person,p" + strconv.Itoa(i) + ",1970\n")
+		for j := 1; j <= 5; j++ {
+			rels.WriteString(strconv.Itoa(i) + "," + strconv.Itoa((i*j+7)%n) + ",KNOWS,2000\n")
+		}
+	}
+	for _, bulk := range []bool{false, true} {
+		b.Run("bulk="+strconv.FormatBool(bulk), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				im := NewImporter(New())
+				im.Bulk = bulk
+				im.SizeHint = n
+				if err := im.Nodes(strings.NewReader(nodes.String())); err != nil {
+					b.Fatal(err)
+				}
+				if err := im.Relationships(strings.NewReader(rels.String())); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
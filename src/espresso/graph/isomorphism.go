@@ -0,0 +1,229 @@
+package graph
+
+import (
+	"reflect"
+)
+
+type MatchOptions struct {
+	VertexLabels bool
+	EdgeLabels   bool
+	Properties   bool
+	Induced      bool
+	Limit        int
+}
+
+// Pattern vertex id to the matched vertex in the target graph.
+type Mapping map[string]*Vertex
+
+type vf2 struct {
+	pattern, target *Graph
+	opt             MatchOptions
+	exact           bool
+	order           []*Vertex
+	parent          map[*Vertex]*Vertex
+	core1           map[*Vertex]*Vertex
+	core2           map[*Vertex]*Vertex
+	results         []Mapping
+}
+
+func newVF2(pattern, target *Graph, opt MatchOptions, exact bool) *vf2 {
+	s := &vf2{
+		pattern: pattern,
+		target:  target,
+		opt:     opt,
+		exact:   exact,
+		parent:  make(map[*Vertex]*Vertex),
+		core1:   make(map[*Vertex]*Vertex),
+		core2:   make(map[*Vertex]*Vertex),
+	}
+	if exact {
+		s.opt.Induced = true
+	}
+	seen := make(map[*Vertex]bool)
+	for _, root := range pattern.Vertices() {
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		queue := []*Vertex{root}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			s.order = append(s.order, v)
+			for _, u := range v.neighbors() {
+				if !seen[u] {
+					seen[u] = true
+					s.parent[u] = v
+					queue = append(queue, u)
+				}
+			}
+		}
+	}
+	return s
+}
+
+// VF2 search; candidates are drawn from the neighborhood of the matched parent.
+func (s *vf2) match(depth int) bool {
+	if depth == len(s.order) {
+		m := make(Mapping, len(s.core1))
+		for p, t := range s.core1 {
+			m[p.id] = t
+		}
+		s.results = append(s.results, m)
+		return s.opt.Limit > 0 && len(s.results) >= s.opt.Limit
+	}
+
+	p := s.order[depth]
+	var candidates []*Vertex
+	if parent, ok := s.parent[p]; ok {
+		candidates = s.core1[parent].neighbors()
+	} else {
+		candidates = s.target.Vertices()
+	}
+
+	for _, t := range candidates {
+		if _, used := s.core2[t]; used || !s.feasible(p, t) {
+			continue
+		}
+		s.core1[p] = t
+		s.core2[t] = p
+		stop := s.match(depth + 1)
+		delete(s.core1, p)
+		delete(s.core2, t)
+		if stop {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *vf2) feasible(p, t *Vertex) bool {
+	if s.opt.VertexLabels && p.label != t.label {
+		return false
+	}
+	if s.opt.Properties && !s.sameData(&p.data, &t.data) {
+		return false
+	}
+	if !s.degrees(p, t) {
+		return false
+	}
+
+	if !s.edgesMatch(p, p, t, t) {
+		return false
+	}
+	for q, u := range s.core1 {
+		if !s.edgesMatch(p, q, t, u) || !s.edgesMatch(q, p, u, t) {
+			return false
+		}
+	}
+
+	// look-ahead: unmatched neighborhoods must be large enough
+	free := func(v *Vertex, core map[*Vertex]*Vertex) int {
+		n := 0
+		for _, u := range v.neighbors() {
+			if _, ok := core[u]; !ok {
+				n++
+			}
+		}
+		return n
+	}
+	fp, ft := free(p, s.core1), free(t, s.core2)
+	if s.exact {
+		return fp == ft
+	}
+	return fp <= ft
+}
+
+func (s *vf2) degrees(p, t *Vertex) bool {
+	po, pi := len(p.outEdges()), len(p.inEdges())
+	to, ti := len(t.outEdges()), len(t.inEdges())
+	if s.exact {
+		return po == to && pi == ti
+	}
+	return po <= to && pi <= ti
+}
+
+func (s *vf2) sameData(p, t *data) bool {
+	if s.exact && p.DataSize() != t.DataSize() {
+		return false
+	}
+	for k, v := range p.values {
+		w, ok := t.Get(k)
+		if !ok || !reflect.DeepEqual(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *vf2) edgesMatch(p, q, t, u *Vertex) bool {
+	pe := s.pattern.Edges(p.id, q.id)
+	te := s.target.Edges(t.id, u.id)
+	if s.opt.Induced && (len(pe) == 0) != (len(te) == 0) {
+		return false
+	}
+	if s.exact && len(pe) != len(te) {
+		return false
+	}
+	if len(pe) > len(te) {
+		return false
+	}
+	used := make([]bool, len(te))
+	var assign func(i int) bool
+	assign = func(i int) bool {
+		if i == len(pe) {
+			return true
+		}
+		for j, e := range te {
+			if used[j] || !s.edgeCompatible(pe[i], e) {
+				continue
+			}
+			used[j] = true
+			if assign(i + 1) {
+				return true
+			}
+			used[j] = false
+		}
+		return false
+	}
+	return assign(0)
+}
+
+func (s *vf2) edgeCompatible(p, t *Edge) bool {
+	if s.opt.EdgeLabels && p.label != t.label {
+		return false
+	}
+	return !s.opt.Properties || s.sameData(&p.data, &t.data)
+}
+
+// Hyperedges are not matched, graphs with any are never isomorphic.
+func Isomorphism(g1, g2 *Graph, opt MatchOptions) (Mapping, bool) {
+	if g1.HyperEdgeCount() > 0 || g2.HyperEdgeCount() > 0 {
+		return nil, false
+	}
+	if g1.Type() != g2.Type() || g1.VertexCount() != g2.VertexCount() || g1.EdgeCount() != g2.EdgeCount() {
+		return nil, false
+	}
+	opt.Limit = 1
+	s := newVF2(g1, g2, opt, true)
+	s.match(0)
+	if len(s.results) == 0 {
+		return nil, false
+	}
+	return s.results[0], true
+}
+
+func IsIsomorphic(g1, g2 *Graph, opt MatchOptions) bool {
+	_, ok := Isomorphism(g1, g2, opt)
+	return ok
+}
+
+// All embeddings of pattern in g, automorphic embeddings are reported separately.
+func SubgraphIsomorphisms(pattern, g *Graph, opt MatchOptions) []Mapping {
+	if pattern.Type() != g.Type() || pattern.VertexCount() > g.VertexCount() {
+		return nil
+	}
+	s := newVF2(pattern, g, opt, false)
+	s.match(0)
+	return s.results
+}
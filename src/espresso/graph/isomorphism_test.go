@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestIsomorphism(t *testing.T) {
+	g1 := NewDirected()
+	g1.Edge("1", "2").Label("knows")
+	g1.Edge("2", "3")
+	g1.Edge("3", "1")
+	g1.Vertex("1").Label("Person")
+
+	g2 := NewDirected()
+	g2.Edge("b", "c")
+	g2.Edge("a", "b")
+	g2.Edge("c", "a").Label("knows")
+	g2.Vertex("c").Label("Person")
+
+	m, ok := Isomorphism(g1, g2, MatchOptions{VertexLabels: true, EdgeLabels: true})
+	if !ok {
+		t.Fatalf("Error graphs should be isomorphic")
+	}
+	if m["1"].id != "c" || m["2"].id != "a" || m["3"].id != "b" {
+		t.Errorf("Error isomorphism mapping: %v", m)
+	}
+
+	g2.Vertex("c").Label("Movie")
+	if IsIsomorphic(g1, g2, MatchOptions{VertexLabels: true}) {
+		t.Errorf("Error labels should prevent isomorphism")
+	}
+	if !IsIsomorphic(g1, g2, MatchOptions{}) {
+		t.Errorf("Error graphs should be isomorphic ignoring labels")
+	}
+
+	g2.Edge("a", "c")
+	if IsIsomorphic(g1, g2, MatchOptions{}) {
+		t.Errorf("Error extra edge should prevent isomorphism")
+	}
+
+	m1, m2 := NewMixed(), NewMixed()
+	m1.Edge("1", "2")
+	m1.HyperEdge("1", "2", "3")
+	m2.Edge("a", "b")
+	m2.HyperEdge("a", "c", "d")
+	if IsIsomorphic(m1, m2, MatchOptions{}) {
+		t.Errorf("Error hyperedges are not matched")
+	}
+}
+
+func TestSubgraphIsomorphisms(t *testing.T) {
+	g := NewUndirected()
+	g.Edge("1", "2")
+	g.Edge("2", "3")
+	g.Edge("3", "1")
+	g.Edge("3", "4")
+	g.Edge("4", "1")
+	g.Vertex("4").Set("kind", "hub")
+
+	triangle := NewUndirected()
+	triangle.Edge("a", "b")
+	triangle.Edge("b", "c")
+	triangle.Edge("c", "a")
+
+	if n := len(SubgraphIsomorphisms(triangle, g, MatchOptions{})); n != 12 {
+		t.Errorf("Error triangle embeddings (2 triangles x 6): %d", n)
+	}
+	if n := len(SubgraphIsomorphisms(triangle, g, MatchOptions{Limit: 5})); n != 5 {
+		t.Errorf("Error triangle embeddings limit (5): %d", n)
+	}
+
+	path := NewUndirected()
+	path.Edge("a", "b")
+	path.Edge("b", "c")
+	if n := len(SubgraphIsomorphisms(path, g, MatchOptions{})); n != 16 {
+		t.Errorf("Error path embeddings (16): %d", n)
+	}
+	if n := len(SubgraphIsomorphisms(path, g, MatchOptions{Induced: true})); n != 4 {
+		t.Errorf("Error induced path embeddings (2-1-4, 2-3-4 x 2): %d", n)
+	}
+
+	path.Vertex("b").Set("kind", "hub")
+	embeddings := SubgraphIsomorphisms(path, g, MatchOptions{Properties: true})
+	for _, m := range embeddings {
+		if m["b"].id != "4" {
+			t.Errorf("Error property match should map b to 4: %v", m)
+		}
+	}
+	if len(embeddings) != 2 {
+		t.Errorf("Error property embeddings (2): %d", len(embeddings))
+	}
+}
@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+type vertexJSON struct {
+	Id    string                 `json:"id"`
+	Label string                 `json:"label,omitempty"`
+	Data  map[string]interface{} `json:"data,omitempty"`
+}
+
+// Undirected edges of a MIXED graph have directed false, hyperedges list vertices instead of from and to.
+type edgeJSON struct {
+	Id       string                 `json:"id,omitempty"`
+	From     string                 `json:"from,omitempty"`
+	To       string                 `json:"to,omitempty"`
+	Vertices []string               `json:"vertices,omitempty"`
+	Directed *bool                  `json:"directed,omitempty"`
+	Label    string                 `json:"label,omitempty"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+}
+
+type graphJSON struct {
+	Type     GraphType              `json:"type"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+	Vertices []*Vertex              `json:"vertices"`
+	Edges    []*Edge                `json:"edges"`
+}
+
+type graphInJSON struct {
+	Type     GraphType              `json:"type"`
+	Data     map[string]interface{} `json:"data"`
+	Vertices []vertexJSON           `json:"vertices"`
+	Edges    []edgeJSON             `json:"edges"`
+}
+
+func (e *edgeJSON) check(t GraphType) error {
+	if e.Vertices == nil && (e.From == "" || e.To == "") {
+		return fmt.Errorf("edge from and to are required")
+	}
+	for _, id := range e.Vertices {
+		if id == "" {
+			return fmt.Errorf("edge vertex ids must not be empty")
+		}
+	}
+	if e.Directed != nil && t != MIXED && *e.Directed != (t == DIRECTED) {
+		return fmt.Errorf("edge direction does not match %s graph", t)
+	}
+	return nil
+}
+
+func (v *Vertex) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vertexJSON{v.id, v.label, v.data.values})
+}
+
+func (e *Edge) MarshalJSON() ([]byte, error) {
+	if e.graph == nil {
+		return nil, fmt.Errorf("edge removed from graph")
+	}
+	out := edgeJSON{Id: e.id, Label: e.label, Data: e.data.values}
+	if e.members != nil {
+		for _, v := range e.members {
+			out.Vertices = append(out.Vertices, v.id)
+		}
+		return json.Marshal(out)
+	}
+	from, to := e.Ends()
+	out.From, out.To = from.id, to.id
+	if e.graph.Type() == MIXED {
+		out.Directed = &e.directed
+	}
+	return json.Marshal(out)
+}
+
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	return json.Marshal(graphJSON{
+		Type:     g._type,
+		Data:     g.data.values,
+		Vertices: g.Vertices(),
+		Edges:    append(g.AllEdges(), g.HyperEdges()...),
+	})
+}
+
+// Replaces the graph contents; numbers in data come back as float64. An
+// attached text index is kept and rebuilt, versioning is not kept.
+func (g *Graph) UnmarshalJSON(raw []byte) error {
+	var in graphInJSON
+	if err := json.Unmarshal(raw, &in); err != nil {
+		return err
+	}
+	switch in.Type {
+	case "", DIRECTED:
+		in.Type = DIRECTED
+	case UNDIRECTED, MIXED:
+	default:
+		return fmt.Errorf("unknown graph type: %s", in.Type)
+	}
+	index := g.index
+	*g = Graph{_type: in.Type}
+	if index != nil {
+		g.index = index
+		index.reset(g)
+	}
+	g.SetMap(in.Data)
+	for _, v := range in.Vertices {
+		if g.HasVertex(v.Id) {
+			return fmt.Errorf("duplicate vertex id: %s", v.Id)
+		}
+		g.Vertex(v.Id).Label(v.Label).SetMap(v.Data)
+	}
+	// ids are reserved up front so generated ones do not take given ones
+	ids := make(map[string]bool, len(in.Edges))
+	for _, e := range in.Edges {
+		if e.Id == "" {
+			continue
+		}
+		if ids[e.Id] {
+			return fmt.Errorf("duplicate edge id: %s", e.Id)
+		}
+		ids[e.Id] = true
+	}
+	for _, e := range in.Edges {
+		if err := e.check(in.Type); err != nil {
+			return err
+		}
+		var edge *Edge
+		switch {
+		case e.Vertices != nil:
+			var err error
+			if edge, err = g.hyperEdge(e.Vertices); err != nil {
+				return err
+			}
+		case e.Directed != nil && !*e.Directed && g._type == MIXED:
+			edge = g.edge(g.Vertex(e.From), g.Vertex(e.To), false)
+		default:
+			edge = g.edge(g.Vertex(e.From), g.Vertex(e.To), g._type != UNDIRECTED)
+		}
+		if edge.id = e.Id; edge.id == "" {
+			for edge.id == "" || ids[edge.id] {
+				g.nextEdge++
+				edge.id = "e" + strconv.Itoa(g.nextEdge)
+			}
+		}
+		g.add(edge).Label(e.Label).SetMap(e.Data)
+	}
+	return nil
+}
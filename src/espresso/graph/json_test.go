@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	g := NewUndirected()
+	g.Set("name", "Pit")
+	g.Vertex("1").Label("Point").Set("x", 1)
+	g.Edge("1", "2").Set("name", "a").Set("size", 3)
+	g.Edge("2", "3").Label("side")
+	g.Edge("2", "3")
+
+	raw, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Error marshal graph: %v", err)
+	}
+
+	var c Graph
+	if err := json.Unmarshal(raw, &c); err != nil {
+		t.Fatalf("Error unmarshal graph: %v", err)
+	}
+	if c.Type() != UNDIRECTED || c.VertexCount() != 3 || c.EdgeCount() != 3 {
+		t.Errorf("Error graph round trip: %s %d %d", c.Type(), c.VertexCount(), c.EdgeCount())
+	}
+	if name, _ := c.GetString("name"); name != "Pit" {
+		t.Errorf("Error graph data round trip: %s", name)
+	}
+	if c.Vertex("1").label != "Point" {
+		t.Errorf("Error vertex label round trip: %v", c.Vertex("1"))
+	}
+	if size, _ := Prop[int](c.Edges("2", "1")[0], "size"); size != 3 {
+		t.Errorf("Error edge data round trip: %d", size)
+	}
+	if n := len(c.Edges("3", "2")); n != 2 {
+		t.Errorf("Error parallel edges round trip (2): %d", n)
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"BIPARTITE"}`), &c); err == nil {
+		t.Errorf("Error unknown graph type should fail")
+	}
+}
+
+func TestMixedJSON(t *testing.T) {
+	g := NewMixed()
+	g.Edge("1", "2").Label("follows")
+	g.UndirectedEdge("2", "3").Label("knows")
+	h, _ := g.HyperEdge("1", "2", "3")
+	h.Label("team").Set("size", 3)
+
+	raw, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Error marshal graph: %v", err)
+	}
+	var c Graph
+	if err := json.Unmarshal(raw, &c); err != nil {
+		t.Fatalf("Error unmarshal graph: %v", err)
+	}
+	if c.Type() != MIXED || c.VertexCount() != 3 || c.EdgeCount() != 2 || c.HyperEdgeCount() != 1 {
+		t.Errorf("Error mixed round trip: %s %d %d %d", c.Type(), c.VertexCount(), c.EdgeCount(), c.HyperEdgeCount())
+	}
+	if e := c.Edges("2", "1"); len(e) != 1 || !e[0].IsHyperedge() {
+		t.Errorf("Error directed edge round trip: %v", e)
+	}
+	if e := c.Edges("3", "2"); len(e) != 2 || e[0].IsDirected() || e[0].GetLabel() != "knows" {
+		t.Errorf("Error undirected edge round trip: %v", e)
+	}
+	if h := c.HyperEdges(); len(h) != 1 || len(h[0].Members()) != 3 || h[0].GetLabel() != "team" {
+		t.Errorf("Error hyperedge round trip: %v", h)
+	}
+}
+
+func TestEdgeIdJSON(t *testing.T) {
+	g := NewDirected()
+	g.Edge("2", "3").Set("n", 1)
+	g.Edge("2", "3").Set("n", 2)
+	g.EdgeWithId("e1x", "3", "2")
+	g.Edges("2", "3")[0].Remove()
+
+	raw, _ := json.Marshal(g)
+	var c Graph
+	if err := json.Unmarshal(raw, &c); err != nil {
+		t.Fatalf("Error unmarshal graph: %v", err)
+	}
+	for _, id := range []string{"e2", "e1x"} {
+		e, f := g.EdgeById(id), c.EdgeById(id)
+		if f == nil || e.String() != f.String() {
+			t.Errorf("Error edge %s round trip: %v %v", id, e, f)
+		}
+	}
+	if c.Edge("1", "2").Id() != "e1" {
+		t.Errorf("Error generated id after round trip: %s", c.Edge("1", "2").Id())
+	}
+
+	var d Graph
+	json.Unmarshal([]byte(`{"edges":[{"from":"a","to":"b"},{"id":"e1","from":"b","to":"a"}]}`), &d)
+	if e := d.EdgeById("e1"); e == nil || e.link["b"] == nil {
+		t.Errorf("Error given id should win over generated: %v", e)
+	}
+	if e := d.Edges("a", "b"); len(e) != 1 || e[0].Id() != "e2" {
+		t.Errorf("Error generated id should skip given ones: %v", e)
+	}
+
+	var p Graph
+	json.Unmarshal([]byte(`{"edges":[{"from":"a","to":"b","label":"first"},{"id":"x","from":"a","to":"b","label":"second"}]}`), &p)
+	if e := p.Edges("a", "b"); len(e) != 2 || e[0].GetLabel() != "first" || e[1].Id() != "x" {
+		t.Errorf("Error parallel edges should keep document order: %v", e)
+	}
+
+	for _, raw := range []string{
+		`{"vertices":[{"id":"a"},{"id":"a"}]}`,
+		`{"edges":[{"id":"x","from":"a","to":"b"},{"id":"x","from":"b","to":"a"}]}`,
+		`{"edges":[{"vertices":["a"]}]}`,
+		`{"edges":[{"from":"a","to":""}]}`,
+		`{"edges":[{"vertices":["a",""]}]}`,
+		`{"type":"DIRECTED","edges":[{"from":"a","to":"b","directed":false}]}`,
+		`{"type":"UNDIRECTED","edges":[{"vertices":["a","b","c"]}]}`,
+	} {
+		if err := json.Unmarshal([]byte(raw), &p); err == nil {
+			t.Errorf("Error invalid graph should fail: %s", raw)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	ErrVertexNotFound = errors.New("vertex not found")
+	ErrNegativeWeight = errors.New("graph has a negative weight")
+)
+
+func nonNegativeWeights(g *Graph, weight string) (map[*Edge]float64, error) {
+	weights, err := edgeWeights(g, weight)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range weights {
+		if w < 0 {
+			return nil, ErrNegativeWeight
+		}
+	}
+	return weights, nil
+}
+
+func ShortestPath(g *Graph, from, to string, weight string) (*Path, error) {
+	paths, err := KShortestPaths(g, from, to, 1, weight)
+	if err != nil || len(paths) == 0 {
+		return nil, err
+	}
+	return paths[0], nil
+}
+
+// Yen's algorithm, paths are loopless and parallel edges make distinct paths.
+func KShortestPaths(g *Graph, from, to string, k int, weight string) ([]*Path, error) {
+	src, ok1 := g.getVertex(from)
+	dst, ok2 := g.getVertex(to)
+	if !ok1 || !ok2 {
+		return nil, ErrVertexNotFound
+	}
+	weights, err := nonNegativeWeights(g, weight)
+	if err != nil {
+		return nil, err
+	}
+	w := func(e *Edge, from, to *Vertex) float64 {
+		return weights[e]
+	}
+
+	dist, prev := dijkstra(src, w, nil)
+	first := treePath(src, dst, dist, prev)
+	if first == nil || k < 1 {
+		return nil, nil
+	}
+
+	paths := []*Path{first}
+	candidates := make([]*Path, 0)
+
+	for len(paths) < k {
+		last := paths[len(paths)-1]
+		for i := 0; i < len(last.Edges); i++ {
+			spur := last.Vertices[i]
+
+			removedEdges := make(map[*Edge]bool)
+			for _, p := range paths {
+				if p.sameEdges(last, i) && len(p.Edges) > i {
+					removedEdges[p.Edges[i]] = true
+				}
+			}
+			removedVertices := make(map[*Vertex]bool)
+			rootWeight := 0.0
+			for j := 0; j < i; j++ {
+				removedVertices[last.Vertices[j]] = true
+				rootWeight += weights[last.Edges[j]]
+			}
+
+			accept := func(e *Edge, u *Vertex) bool {
+				return !removedEdges[e] && !removedVertices[u]
+			}
+			dist, prev := dijkstra(spur, w, accept)
+			tail := treePath(spur, dst, dist, prev)
+			if tail == nil {
+				continue
+			}
+
+			p := &Path{
+				Vertices: append(append([]*Vertex{}, last.Vertices[:i]...), tail.Vertices...),
+				Edges:    append(append([]*Edge{}, last.Edges[:i]...), tail.Edges...),
+				Weight:   rootWeight + tail.Weight,
+			}
+			if !containsPath(candidates, p) && !containsPath(paths, p) {
+				candidates = append(candidates, p)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if candidates[i].Weight != candidates[j].Weight {
+				return candidates[i].Weight < candidates[j].Weight
+			}
+			return len(candidates[i].Edges) < len(candidates[j].Edges)
+		})
+		paths = append(paths, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	return paths, nil
+}
+
+func containsPath(paths []*Path, p *Path) bool {
+	for _, q := range paths {
+		if len(q.Edges) == len(p.Edges) && q.sameEdges(p, len(p.Edges)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"testing"
+)
+
+func routeGraph() *Graph {
+	g := NewDirected()
+	g.Edge("C", "D").Set("km", 3)
+	g.Edge("C", "E").Set("km", 2)
+	g.Edge("D", "F").Set("km", 4)
+	g.Edge("E", "D").Set("km", 1)
+	g.Edge("E", "F").Set("km", 2)
+	g.Edge("E", "G").Set("km", 3)
+	g.Edge("F", "G").Set("km", 2)
+	g.Edge("F", "H").Set("km", 1)
+	g.Edge("G", "H").Set("km", 2)
+	return g
+}
+
+func TestKShortestPaths(t *testing.T) {
+	g := routeGraph()
+
+	paths, err := KShortestPaths(g, "C", "H", 3, "km")
+	if err != nil {
+		t.Fatalf("Error k shortest paths: %v", err)
+	}
+	expected := []struct {
+		weight float64
+		out    string
+	}{
+		{5, "(C)-[{km:2}]->(E)-[{km:2}]->(F)-[{km:1}]->(H)"},
+		{7, "(C)-[{km:2}]->(E)-[{km:3}]->(G)-[{km:2}]->(H)"},
+		{8, "(C)-[{km:3}]->(D)-[{km:4}]->(F)-[{km:1}]->(H)"},
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("Error k shortest paths size (%d): %d", len(expected), len(paths))
+	}
+	for i, p := range paths {
+		if p.Weight != expected[i].weight {
+			t.Errorf("Error path %d weight (%g): %g", i, expected[i].weight, p.Weight)
+		}
+		if i != 2 && p.String() != expected[i].out {
+			t.Errorf("Error path %d (%s): %s", i, expected[i].out, p)
+		}
+	}
+
+	all, _ := KShortestPaths(g, "C", "H", 100, "km")
+	if n := len(all); n != 7 {
+		t.Errorf("Error all loopless paths (7): %d", n)
+	}
+
+	p, err := ShortestPath(g, "H", "C", "km")
+	if err != nil || p != nil {
+		t.Errorf("Error no path from H to C: %v, %v", p, err)
+	}
+	if _, err := ShortestPath(g, "C", "Z", "km"); err != ErrVertexNotFound {
+		t.Errorf("Error missing vertex: %v", err)
+	}
+	g.Edge("C", "H").Set("km", -1)
+	if _, err := ShortestPath(g, "C", "H", "km"); err != ErrNegativeWeight {
+		t.Errorf("Error negative weight: %v", err)
+	}
+}
+
+func TestKShortestPathsParallel(t *testing.T) {
+	g := NewUndirected()
+	g.Edge("1", "2").Label("road")
+	g.Edge("2", "1").Label("rail")
+
+	paths, err := KShortestPaths(g, "1", "2", 5, "")
+	if err != nil {
+		t.Fatalf("Error k shortest paths: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("Error parallel edges should give 2 paths: %d", len(paths))
+	}
+	if out := paths[0].String(); out != "(1)-[:road ]-(2)" {
+		t.Errorf("Error undirected path string: %s", out)
+	}
+}
@@ -0,0 +1,179 @@
+package layout
+
+import (
+	"espresso/graph"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+type Point struct {
+	X, Y float64
+}
+
+// Vertex positions keyed by vertex id.
+type Layout map[string]Point
+
+type Options struct {
+	Width, Height float64
+	Iterations    int
+	Seed          int64
+}
+
+func (o *Options) defaults() {
+	if o.Width <= 0 {
+		o.Width = 800
+	}
+	if o.Height <= 0 {
+		o.Height = 600
+	}
+	if o.Iterations <= 0 {
+		o.Iterations = 300
+	}
+}
+
+// Fruchterman-Reingold force-directed placement inside Width x Height.
+func FruchtermanReingold(g *graph.Graph, opt Options) Layout {
+	opt.defaults()
+	vertices := g.Vertices()
+	n := len(vertices)
+	out := make(Layout, n)
+	if n == 0 {
+		return out
+	}
+
+	r := rand.New(rand.NewSource(opt.Seed))
+	index := make(map[*graph.Vertex]int, n)
+	pos := make([]Point, n)
+	for i, v := range vertices {
+		index[v] = i
+		pos[i] = Point{r.Float64() * opt.Width, r.Float64() * opt.Height}
+	}
+	type pair struct{ a, b int }
+	links := make([]pair, 0)
+	for _, e := range g.AllEdges() {
+		from, to := e.Ends()
+		if from != to {
+			links = append(links, pair{index[from], index[to]})
+		}
+	}
+
+	k := math.Sqrt(opt.Width * opt.Height / float64(n))
+	temperature := opt.Width / 10
+	cooling := temperature / float64(opt.Iterations+1)
+	disp := make([]Point, n)
+
+	for iteration := 0; iteration < opt.Iterations; iteration++ {
+		for i := range disp {
+			disp[i] = Point{}
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				dx, dy := pos[i].X-pos[j].X, pos[i].Y-pos[j].Y
+				d := math.Max(math.Hypot(dx, dy), 0.01)
+				f := k * k / d
+				disp[i].X += dx / d * f
+				disp[i].Y += dy / d * f
+				disp[j].X -= dx / d * f
+				disp[j].Y -= dy / d * f
+			}
+		}
+		for _, l := range links {
+			dx, dy := pos[l.a].X-pos[l.b].X, pos[l.a].Y-pos[l.b].Y
+			d := math.Max(math.Hypot(dx, dy), 0.01)
+			f := d * d / k
+			disp[l.a].X -= dx / d * f
+			disp[l.a].Y -= dy / d * f
+			disp[l.b].X += dx / d * f
+			disp[l.b].Y += dy / d * f
+		}
+		for i := range pos {
+			d := math.Max(math.Hypot(disp[i].X, disp[i].Y), 0.01)
+			step := math.Min(d, temperature)
+			pos[i].X = math.Min(opt.Width, math.Max(0, pos[i].X+disp[i].X/d*step))
+			pos[i].Y = math.Min(opt.Height, math.Max(0, pos[i].Y+disp[i].Y/d*step))
+		}
+		temperature -= cooling
+	}
+
+	for i, v := range vertices {
+		out[v.Id()] = pos[i]
+	}
+	return out
+}
+
+// Layered drawing of a DAG: layer by longest path from the sources,
+// order inside layers by barycenter sweeps.
+func Hierarchical(g *graph.Graph, opt Options) (Layout, error) {
+	opt.defaults()
+	order, err := graph.TopologicalSort(g)
+	if err != nil {
+		return nil, err
+	}
+
+	layer := make(map[*graph.Vertex]int, len(order))
+	parents := make(map[*graph.Vertex][]*graph.Vertex)
+	children := make(map[*graph.Vertex][]*graph.Vertex)
+	depth := 0
+	for _, e := range g.AllEdges() {
+		from, to := e.Ends()
+		parents[to] = append(parents[to], from)
+		children[from] = append(children[from], to)
+	}
+	for _, v := range order {
+		for _, p := range parents[v] {
+			if layer[p]+1 > layer[v] {
+				layer[v] = layer[p] + 1
+			}
+		}
+		if layer[v] > depth {
+			depth = layer[v]
+		}
+	}
+
+	layers := make([][]*graph.Vertex, depth+1)
+	for _, v := range order {
+		layers[layer[v]] = append(layers[layer[v]], v)
+	}
+	rank := make(map[*graph.Vertex]float64, len(order))
+	for _, l := range layers {
+		for i, v := range l {
+			rank[v] = float64(i)
+		}
+	}
+	sweep := func(l []*graph.Vertex, adjacent map[*graph.Vertex][]*graph.Vertex) {
+		center := make(map[*graph.Vertex]float64, len(l))
+		for _, v := range l {
+			center[v] = rank[v]
+			if adj := adjacent[v]; len(adj) > 0 {
+				sum := 0.0
+				for _, u := range adj {
+					sum += rank[u]
+				}
+				center[v] = sum / float64(len(adj))
+			}
+		}
+		sort.SliceStable(l, func(i, j int) bool { return center[l[i]] < center[l[j]] })
+		for i, v := range l {
+			rank[v] = float64(i)
+		}
+	}
+	for iteration := 0; iteration < 4; iteration++ {
+		for i := 1; i < len(layers); i++ {
+			sweep(layers[i], parents)
+		}
+		for i := len(layers) - 2; i >= 0; i-- {
+			sweep(layers[i], children)
+		}
+	}
+
+	out := make(Layout, len(order))
+	dy := opt.Height / float64(len(layers)+1)
+	for i, l := range layers {
+		dx := opt.Width / float64(len(l)+1)
+		for j, v := range l {
+			out[v.Id()] = Point{dx * float64(j+1), dy * float64(i+1)}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,66 @@
+package layout
+
+import (
+	"espresso/graph"
+	"math"
+	"testing"
+)
+
+func TestFruchtermanReingold(t *testing.T) {
+	g := graph.NewUndirected()
+	g.Edge("1", "2")
+	g.Edge("2", "3")
+	g.Edge("3", "1")
+	g.Edge("3", "4")
+	g.Vertex("5")
+
+	opt := Options{Width: 400, Height: 300, Seed: 1}
+	l := FruchtermanReingold(g, opt)
+	if len(l) != 5 {
+		t.Fatalf("Error layout size (5): %d", len(l))
+	}
+	for id, p := range l {
+		if p.X < 0 || p.X > 400 || p.Y < 0 || p.Y > 300 || math.IsNaN(p.X) || math.IsNaN(p.Y) {
+			t.Errorf("Error vertex %s out of bounds: %v", id, p)
+		}
+	}
+	for _, a := range []string{"1", "2", "3", "4", "5"} {
+		for _, b := range []string{"1", "2", "3", "4", "5"} {
+			if a < b && l[a] == l[b] {
+				t.Errorf("Error vertices %s and %s overlap: %v", a, b, l[a])
+			}
+		}
+	}
+
+	again := FruchtermanReingold(g, opt)
+	for id, p := range l {
+		if again[id] != p {
+			t.Errorf("Error layout should be reproducible for vertex %s: %v, %v", id, p, again[id])
+		}
+	}
+}
+
+func TestHierarchical(t *testing.T) {
+	g := graph.NewDirected()
+	g.Edge("a", "b")
+	g.Edge("a", "c")
+	g.Edge("b", "d")
+	g.Edge("c", "d")
+	g.Edge("a", "d")
+
+	l, err := Hierarchical(g, Options{})
+	if err != nil {
+		t.Fatalf("Error hierarchical layout: %v", err)
+	}
+	if !(l["a"].Y < l["b"].Y && l["b"].Y == l["c"].Y && l["c"].Y < l["d"].Y) {
+		t.Errorf("Error layers a < b = c < d: %v", l)
+	}
+	if l["b"].X == l["c"].X {
+		t.Errorf("Error same layer vertices overlap: %v", l)
+	}
+
+	g.Edge("d", "a")
+	if _, err := Hierarchical(g, Options{}); err != graph.ErrCycle {
+		t.Errorf("Error cyclic graph layout: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package layout
+
+import (
+	"espresso/graph"
+	"fmt"
+	"html"
+	"io"
+	"math"
+)
+
+type Style struct {
+	Width, Height float64
+	Radius        float64
+	Margin        float64
+}
+
+func (s *Style) defaults() {
+	if s.Width <= 0 {
+		s.Width = 800
+	}
+	if s.Height <= 0 {
+		s.Height = 600
+	}
+	if s.Radius <= 0 {
+		s.Radius = 18
+	}
+	if s.Margin <= 0 {
+		s.Margin = 3 * s.Radius
+	}
+}
+
+// Scales the layout into the drawing area, keeping the aspect ratio.
+func (l Layout) fit(s *Style) Layout {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range l {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	w, h := s.Width-2*s.Margin, s.Height-2*s.Margin
+	scale := math.Min(w/math.Max(maxX-minX, 1e-9), h/math.Max(maxY-minY, 1e-9))
+	offX := s.Margin + (w-(maxX-minX)*scale)/2
+	offY := s.Margin + (h-(maxY-minY)*scale)/2
+	out := make(Layout, len(l))
+	for id, p := range l {
+		out[id] = Point{offX + (p.X-minX)*scale, offY + (p.Y-minY)*scale}
+	}
+	return out
+}
+
+func vertexText(v *graph.Vertex) string {
+	if label := v.GetLabel(); label != "" {
+		return v.Id() + ":" + label
+	}
+	return v.Id()
+}
+
+func SVG(w io.Writer, g *graph.Graph, l Layout, s Style) error {
+	s.defaults()
+	pos := l.fit(&s)
+	directed := g.Type() != graph.UNDIRECTED
+
+	out := &svgWriter{w: w}
+	out.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n",
+		s.Width, s.Height, s.Width, s.Height)
+	out.printf(`<style>text{font-family:sans-serif;font-size:11px}</style>` + "\n")
+	if directed {
+		out.printf(`<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto-start-reverse"><path d="M0,0 L10,5 L0,10 z" fill="#555"/></marker></defs>` + "\n")
+	}
+
+	// parallel edges between the same pair are spread apart as curves
+	type pair struct{ a, b string }
+	key := func(from, to *graph.Vertex) pair {
+		if from.Id() > to.Id() {
+			return pair{to.Id(), from.Id()}
+		}
+		return pair{from.Id(), to.Id()}
+	}
+	parallel := make(map[pair]int)
+	for _, e := range g.AllEdges() {
+		parallel[key(e.Ends())]++
+	}
+	drawn := make(map[pair]int)
+
+	for _, e := range g.AllEdges() {
+		from, to := e.Ends()
+		key := key(from, to)
+		nth := drawn[key]
+		drawn[key]++
+		p1, p2 := pos[from.Id()], pos[to.Id()]
+
+		var d string
+		var mid Point
+		if from == to {
+			r := s.Radius * (1 + 0.5*float64(nth))
+			d = fmt.Sprintf("M%.1f,%.1f a%.1f,%.1f 0 1,1 %.1f,0", p1.X-s.Radius/2, p1.Y-s.Radius, r, r, s.Radius)
+			mid = Point{p1.X, p1.Y - s.Radius - 2*r}
+		} else {
+			a, b := pos[key.a], pos[key.b]
+			dx, dy := b.X-a.X, b.Y-a.Y
+			length := math.Max(math.Hypot(dx, dy), 1e-9)
+			offset := (float64(nth) - float64(parallel[key]-1)/2) * 2 * s.Radius
+			c := Point{(p1.X+p2.X)/2 - dy/length*offset, (p1.Y+p2.Y)/2 + dx/length*offset}
+			start := toward(p1, c, s.Radius)
+			end := toward(p2, c, s.Radius)
+			d = fmt.Sprintf("M%.1f,%.1f Q%.1f,%.1f %.1f,%.1f", start.X, start.Y, c.X, c.Y, end.X, end.Y)
+			mid = Point{(p1.X + 2*c.X + p2.X) / 4, (p1.Y + 2*c.Y + p2.Y) / 4}
+		}
+		marker := ""
+		if e.IsDirected() {
+			marker = ` marker-end="url(#arrow)"`
+		}
+		out.printf(`<path d="%s" fill="none" stroke="#555"%s/>`+"\n", d, marker)
+		if label := e.GetLabel(); label != "" {
+			out.printf(`<text x="%.1f" y="%.1f" text-anchor="middle" fill="#555">%s</text>`+"\n",
+				mid.X, mid.Y-3, html.EscapeString(label))
+		}
+	}
+
+	for _, v := range g.Vertices() {
+		p := pos[v.Id()]
+		out.printf(`<circle cx="%.1f" cy="%.1f" r="%g" fill="#fff" stroke="#222"/>`+"\n", p.X, p.Y, s.Radius)
+		out.printf(`<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`+"\n",
+			p.X, p.Y+s.Radius+12, html.EscapeString(vertexText(v)))
+	}
+	out.printf("</svg>\n")
+	return out.err
+}
+
+func toward(from, to Point, distance float64) Point {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	length := math.Max(math.Hypot(dx, dy), 1e-9)
+	return Point{from.X + dx/length*distance, from.Y + dy/length*distance}
+}
+
+type svgWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (s *svgWriter) printf(format string, args ...interface{}) {
+	if s.err != nil {
+		return
+	}
+	_, s.err = fmt.Fprintf(s.w, format, args...)
+}
@@ -0,0 +1,43 @@
+package layout
+
+import (
+	"bytes"
+	"espresso/graph"
+	"strings"
+	"testing"
+)
+
+func TestSVG(t *testing.T) {
+	g := graph.NewDirected()
+	g.Vertex("3").Label("Actor")
+	g.Vertex("0").Label("Movie")
+	g.Edge("3", "0").Label("ACTS_IN")
+	g.Edge("3", "0").Label("DIRECTED")
+	g.Edge("0", "0")
+
+	var out bytes.Buffer
+	if err := SVG(&out, g, FruchtermanReingold(g, Options{}), Style{}); err != nil {
+		t.Fatalf("Error rendering svg: %v", err)
+	}
+	svg := out.String()
+
+	for _, s := range []string{"<svg", "3:Actor", "0:Movie", "ACTS_IN", "DIRECTED", `marker-end="url(#arrow)"`, "</svg>"} {
+		if !strings.Contains(svg, s) {
+			t.Errorf("Error svg should contain %q", s)
+		}
+	}
+	if n := strings.Count(svg, `fill="none"`); n != 3 {
+		t.Errorf("Error svg edges (3): %d", n)
+	}
+	if n := strings.Count(svg, "<circle"); n != 2 {
+		t.Errorf("Error svg vertices (2): %d", n)
+	}
+
+	u := graph.NewUndirected()
+	u.Edge("a", "b").Label("<x>")
+	out.Reset()
+	SVG(&out, u, Layout{"a": {0, 0}, "b": {1, 1}}, Style{})
+	if svg := out.String(); strings.Contains(svg, "marker-end") || !strings.Contains(svg, "&lt;x&gt;") {
+		t.Errorf("Error undirected svg: %s", svg)
+	}
+}
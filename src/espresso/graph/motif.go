@@ -0,0 +1,61 @@
+package graph
+
+// Edge direction, parallel edges and self-loops are ignored.
+func Triangles(g *Graph) (map[string]int, int) {
+	vertices := g.Vertices()
+	rank := make(map[*Vertex]int, len(vertices))
+	adjacent := make(map[*Vertex]map[*Vertex]bool, len(vertices))
+	for i, v := range vertices {
+		rank[v] = i
+		adjacent[v] = make(map[*Vertex]bool)
+		for _, u := range v.neighbors() {
+			adjacent[v][u] = true
+		}
+	}
+
+	counts := make(map[string]int, len(vertices))
+	total := 0
+	for _, v := range vertices {
+		counts[v.id] += 0
+		for u := range adjacent[v] {
+			if rank[u] <= rank[v] {
+				continue
+			}
+			for w := range adjacent[u] {
+				if rank[w] <= rank[u] || !adjacent[v][w] {
+					continue
+				}
+				counts[v.id]++
+				counts[u.id]++
+				counts[w.id]++
+				total++
+			}
+		}
+	}
+	return counts, total
+}
+
+func ClusteringCoefficient(g *Graph) map[string]float64 {
+	triangles, _ := Triangles(g)
+	out := make(map[string]float64, len(g.vertices))
+	for _, v := range g.vertices {
+		d := len(v.neighbors())
+		if d < 2 {
+			out[v.id] = 0
+			continue
+		}
+		out[v.id] = 2 * float64(triangles[v.id]) / float64(d*(d-1))
+	}
+	return out
+}
+
+func AverageClustering(g *Graph) float64 {
+	if len(g.vertices) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, c := range ClusteringCoefficient(g) {
+		sum += c
+	}
+	return sum / float64(len(g.vertices))
+}
@@ -0,0 +1,36 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestTriangles(t *testing.T) {
+	g := NewUndirected()
+	g.Edge("1", "2")
+	g.Edge("2", "3")
+	g.Edge("3", "1")
+	g.Edge("3", "1")
+	g.Edge("3", "4")
+	g.Edge("4", "1")
+	g.Edge("4", "5")
+	g.Edge("5", "5")
+
+	counts, total := Triangles(g)
+	if total != 2 {
+		t.Errorf("Error triangles (2): %d", total)
+	}
+	if counts["1"] != 2 || counts["2"] != 1 || counts["5"] != 0 {
+		t.Errorf("Error triangle counts: %v", counts)
+	}
+
+	cc := ClusteringCoefficient(g)
+	if cc["2"] != 1 || cc["5"] != 0 {
+		t.Errorf("Error clustering coefficient: %v", cc)
+	}
+	if c := cc["1"]; c != 2.0/3 {
+		t.Errorf("Error clustering coefficient of 1 (2/3): %g", c)
+	}
+	if c := cc["4"]; c != 1.0/3 {
+		t.Errorf("Error clustering coefficient of 4 (1/3): %g", c)
+	}
+}
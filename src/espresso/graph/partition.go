@@ -0,0 +1,436 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+var ErrPartitionCount = errors.New("number of parts must be positive")
+
+// Weight and VertexWeight name edge and vertex properties, 1 when empty or
+// missing. Balance is the allowed imbalance, 0.03 when zero.
+type PartitionOptions struct {
+	Weight       string
+	VertexWeight string
+	Balance      float64
+	Seed         int64
+}
+
+// Parts maps vertex ids to parts numbered from 0; Imbalance is the heaviest
+// part relative to a perfect split, minus one.
+type Partition struct {
+	Parts     map[string]int
+	Weights   []float64
+	Cut       float64
+	Imbalance float64
+}
+
+// Undirected weighted graph over vertex indices, parallel edges merged and self-loops dropped.
+type wgraph struct {
+	vw   []float64
+	adj  []map[int]float64
+	nbrs [][]int
+}
+
+func newWgraph(n int) *wgraph {
+	h := &wgraph{vw: make([]float64, n), adj: make([]map[int]float64, n)}
+	for i := range h.adj {
+		h.adj[i] = make(map[int]float64)
+	}
+	return h
+}
+
+func (h *wgraph) total() float64 {
+	t := 0.0
+	for _, w := range h.vw {
+		t += w
+	}
+	return t
+}
+
+// Neighbors in index order so runs, and float sums, are reproducible; built
+// once the graph is complete.
+func (h *wgraph) neighbors(v int) []int {
+	if h.nbrs == nil {
+		h.nbrs = make([][]int, len(h.adj))
+		for i, adj := range h.adj {
+			h.nbrs[i] = make([]int, 0, len(adj))
+			for u := range adj {
+				h.nbrs[i] = append(h.nbrs[i], u)
+			}
+			sort.Ints(h.nbrs[i])
+		}
+	}
+	return h.nbrs[v]
+}
+
+// Heavy-edge matching; returns the coarse graph and the coarse index of every vertex.
+func (h *wgraph) coarsen(r *rand.Rand) (*wgraph, []int) {
+	n := len(h.vw)
+	cmap := make([]int, n)
+	for i := range cmap {
+		cmap[i] = -1
+	}
+	c := 0
+	for _, v := range r.Perm(n) {
+		if cmap[v] >= 0 {
+			continue
+		}
+		best, bw := -1, 0.0
+		for _, u := range h.neighbors(v) {
+			if w := h.adj[v][u]; cmap[u] < 0 && (best < 0 || w > bw) {
+				best, bw = u, w
+			}
+		}
+		cmap[v] = c
+		if best >= 0 {
+			cmap[best] = c
+		}
+		c++
+	}
+	coarse := newWgraph(c)
+	for v := 0; v < n; v++ {
+		cv := cmap[v]
+		coarse.vw[cv] += h.vw[v]
+		for _, u := range h.neighbors(v) {
+			w := h.adj[v][u]
+			if cu := cmap[u]; cu != cv {
+				coarse.adj[cv][cu] += w
+			}
+		}
+	}
+	return coarse, cmap
+}
+
+// Vertices on one side of a bisection, with their original indices.
+func (h *wgraph) induced(side []int, s int, ids []int) (*wgraph, []int) {
+	index := make(map[int]int)
+	var sub []int
+	for v := range h.vw {
+		if side[v] == s {
+			index[v] = len(sub)
+			sub = append(sub, ids[v])
+		}
+	}
+	out := newWgraph(len(sub))
+	for v, i := range index {
+		out.vw[i] = h.vw[v]
+		for _, u := range h.neighbors(v) {
+			w := h.adj[v][u]
+			if j, ok := index[u]; ok {
+				out.adj[i][j] = w
+			}
+		}
+	}
+	return out, sub
+}
+
+type gainItem struct {
+	v    int
+	gain float64
+}
+
+type gainHeap []gainItem
+
+func (q gainHeap) Len() int { return len(q) }
+func (q gainHeap) Less(i, j int) bool {
+	return q[i].gain > q[j].gain || q[i].gain == q[j].gain && q[i].v < q[j].v
+}
+func (q gainHeap) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *gainHeap) Push(x interface{}) { *q = append(*q, x.(gainItem)) }
+func (q *gainHeap) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+type partitioner struct {
+	rand *rand.Rand
+	eps  float64
+}
+
+// Side 0 gets frac of the total weight.
+func (p *partitioner) bisect(h *wgraph, frac float64) []int {
+	levels := []*wgraph{h}
+	var maps [][]int
+	for cur := h; len(cur.vw) > 40; {
+		c, cmap := cur.coarsen(p.rand)
+		if len(c.vw) > len(cur.vw)*9/10 {
+			break
+		}
+		levels = append(levels, c)
+		maps = append(maps, cmap)
+		cur = c
+	}
+	side := p.initial(levels[len(levels)-1], frac)
+	for i := len(maps) - 1; i >= 0; i-- {
+		fine := make([]int, len(levels[i].vw))
+		for v := range fine {
+			fine[v] = side[maps[i][v]]
+		}
+		side = fine
+		p.refine(levels[i], side, frac)
+	}
+	return side
+}
+
+// Greedy graph growing from a few random seeds, the best refined result wins.
+func (p *partitioner) initial(h *wgraph, frac float64) []int {
+	n := len(h.vw)
+	target := frac * h.total()
+	var best []int
+	bestOver, bestCut := math.Inf(1), math.Inf(1)
+	for try := 0; try < 8 && try < n; try++ {
+		side := make([]int, n)
+		for i := range side {
+			side[i] = 1
+		}
+		gain := make([]float64, n)
+		for v := range h.adj {
+			for _, u := range h.neighbors(v) {
+				gain[v] -= h.adj[v][u]
+			}
+		}
+		order := p.rand.Perm(n)
+		q := &gainHeap{{order[0], gain[order[0]]}}
+		weight := 0.0
+		for next := 1; weight < target; {
+			if q.Len() == 0 {
+				for next < n && side[order[next]] == 0 {
+					next++
+				}
+				if next == n {
+					break
+				}
+				heap.Push(q, gainItem{order[next], gain[order[next]]})
+				next++
+			}
+			item := heap.Pop(q).(gainItem)
+			v := item.v
+			if side[v] == 0 || item.gain != gain[v] {
+				continue
+			}
+			if weight > 0 && weight+h.vw[v] > target*(1+p.eps) {
+				continue
+			}
+			side[v] = 0
+			weight += h.vw[v]
+			for _, u := range h.neighbors(v) {
+				if side[u] == 1 {
+					gain[u] += 2 * h.adj[v][u]
+					heap.Push(q, gainItem{u, gain[u]})
+				}
+			}
+		}
+		p.refine(h, side, frac)
+		if over, cut := overload(h, side, frac, p.eps), cutWeight(h, side); over < bestOver || over == bestOver && cut < bestCut {
+			best, bestOver, bestCut = side, over, cut
+		}
+	}
+	if best == nil {
+		best = make([]int, n)
+	}
+	return best
+}
+
+func sideWeights(h *wgraph, side []int) [2]float64 {
+	var w [2]float64
+	for v, s := range side {
+		w[s] += h.vw[v]
+	}
+	return w
+}
+
+func sideLimits(h *wgraph, frac, eps float64) [2]float64 {
+	t := h.total()
+	return [2]float64{frac * t * (1 + eps), (1 - frac) * t * (1 + eps)}
+}
+
+func overload(h *wgraph, side []int, frac, eps float64) float64 {
+	w, max := sideWeights(h, side), sideLimits(h, frac, eps)
+	return math.Max(0, w[0]-max[0]) + math.Max(0, w[1]-max[1])
+}
+
+func cutWeight(h *wgraph, side []int) float64 {
+	cut := 0.0
+	for v := range h.adj {
+		for _, u := range h.neighbors(v) {
+			w := h.adj[v][u]
+			if v < u && side[u] != side[v] {
+				cut += w
+			}
+		}
+	}
+	return cut
+}
+
+// Fiduccia-Mattheyses passes: moves of highest gain within the balance limits,
+// rolled back to the best prefix, until a pass brings no improvement.
+func (p *partitioner) refine(h *wgraph, side []int, frac float64) {
+	n := len(h.vw)
+	max := sideLimits(h, frac, p.eps)
+	for pass := 0; pass < 10; pass++ {
+		weights := sideWeights(h, side)
+		over := func() float64 {
+			return math.Max(0, weights[0]-max[0]) + math.Max(0, weights[1]-max[1])
+		}
+		gain := make([]float64, n)
+		queues := [2]*gainHeap{{}, {}}
+		for v := range h.adj {
+			for _, u := range h.neighbors(v) {
+				w := h.adj[v][u]
+				if side[u] != side[v] {
+					gain[v] += w
+				} else {
+					gain[v] -= w
+				}
+			}
+			*queues[side[v]] = append(*queues[side[v]], gainItem{v, gain[v]})
+		}
+		heap.Init(queues[0])
+		heap.Init(queues[1])
+
+		locked := make([]bool, n)
+		var moves []int
+		cur, best, bestOver, bestAt := 0.0, 0.0, over(), 0
+		for len(moves)-bestAt <= 50+n/10 {
+			v := -1
+			for from := 0; from < 2; from++ {
+				q := queues[from]
+				for q.Len() > 0 {
+					top := (*q)[0]
+					if !locked[top.v] && side[top.v] == from && top.gain == gain[top.v] {
+						break
+					}
+					heap.Pop(q)
+				}
+				if q.Len() == 0 {
+					continue
+				}
+				u := (*q)[0].v
+				to := 1 - from
+				fits := weights[to]+h.vw[u] <= max[to] || weights[from] > max[from] && weights[to]+h.vw[u] < weights[from]
+				if fits && (v < 0 || gain[u] > gain[v]) {
+					v = u
+				}
+			}
+			if v < 0 {
+				break
+			}
+			from := side[v]
+			heap.Pop(queues[from])
+			locked[v] = true
+			side[v] = 1 - from
+			weights[from] -= h.vw[v]
+			weights[1-from] += h.vw[v]
+			cur += gain[v]
+			moves = append(moves, v)
+			for _, u := range h.neighbors(v) {
+				w := h.adj[v][u]
+				if locked[u] {
+					continue
+				}
+				if side[u] == side[v] {
+					gain[u] -= 2 * w
+				} else {
+					gain[u] += 2 * w
+				}
+				heap.Push(queues[side[u]], gainItem{u, gain[u]})
+			}
+			if o := over(); o < bestOver || o == bestOver && cur > best+1e-9 {
+				best, bestOver, bestAt = cur, o, len(moves)
+			}
+		}
+		for _, v := range moves[bestAt:] {
+			side[v] = 1 - side[v]
+		}
+		if bestAt == 0 {
+			return
+		}
+	}
+}
+
+func (p *partitioner) split(h *wgraph, ids []int, k, first int, parts []int) {
+	if k == 1 || len(h.vw) == 0 {
+		for _, i := range ids {
+			parts[i] = first
+		}
+		return
+	}
+	k1 := k / 2
+	side := p.bisect(h, float64(k1)/float64(k))
+	h0, ids0 := h.induced(side, 0, ids)
+	h1, ids1 := h.induced(side, 1, ids)
+	p.split(h0, ids0, k1, first, parts)
+	p.split(h1, ids1, k-k1, first+k1, parts)
+}
+
+// Recursive multilevel bisection into k parts of balanced vertex weight with
+// few cut edges. Edge direction is ignored.
+func MultilevelPartition(g *Graph, k int, opt PartitionOptions) (*Partition, error) {
+	if k < 1 {
+		return nil, ErrPartitionCount
+	}
+	weights, err := nonNegativeWeights(g, opt.Weight)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Balance <= 0 {
+		opt.Balance = 0.03
+	}
+
+	vertices := g.Vertices()
+	index := make(map[*Vertex]int, len(vertices))
+	h := newWgraph(len(vertices))
+	ids := make([]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+		ids[i] = i
+		h.vw[i] = 1
+		if _, ok := v.Get(opt.VertexWeight); ok && opt.VertexWeight != "" {
+			if h.vw[i], err = v.GetFloat(opt.VertexWeight); err != nil {
+				return nil, err
+			}
+			if h.vw[i] < 0 {
+				return nil, ErrNegativeWeight
+			}
+		}
+	}
+	edges := g.AllEdges()
+	for _, e := range edges {
+		from, to := e.Ends()
+		if i, j := index[from], index[to]; i != j {
+			h.adj[i][j] += weights[e]
+			h.adj[j][i] += weights[e]
+		}
+	}
+
+	levels := math.Ceil(math.Log2(float64(k)))
+	p := &partitioner{rand: rand.New(rand.NewSource(opt.Seed)), eps: opt.Balance / math.Max(levels, 1)}
+	parts := make([]int, len(vertices))
+	p.split(h, ids, k, 0, parts)
+
+	result := &Partition{Parts: make(map[string]int, len(vertices)), Weights: make([]float64, k)}
+	for i, v := range vertices {
+		result.Parts[v.id] = parts[i]
+		result.Weights[parts[i]] += h.vw[i]
+	}
+	for _, e := range edges {
+		from, to := e.Ends()
+		if parts[index[from]] != parts[index[to]] {
+			result.Cut += weights[e]
+		}
+	}
+	if total := h.total(); total > 0 {
+		heaviest := 0.0
+		for _, w := range result.Weights {
+			heaviest = math.Max(heaviest, w)
+		}
+		result.Imbalance = heaviest/(total/float64(k)) - 1
+	}
+	return result, nil
+}
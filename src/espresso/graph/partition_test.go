@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMultilevelPartition(t *testing.T) {
+	// two 10-cliques joined by one edge
+	g := NewUndirected()
+	for c := 0; c < 2; c++ {
+		for i := 0; i < 10; i++ {
+			for j := i + 1; j < 10; j++ {
+				g.Edge(fmt.Sprintf("%d-%d", c, i), fmt.Sprintf("%d-%d", c, j))
+			}
+		}
+	}
+	g.Edge("0-0", "1-0")
+
+	p, err := MultilevelPartition(g, 2, PartitionOptions{})
+	if err != nil {
+		t.Fatalf("Error partitioning: %v", err)
+	}
+	if p.Cut != 1 || p.Imbalance != 0 || p.Weights[0] != 10 || p.Weights[1] != 10 {
+		t.Errorf("Error cliques partition (cut 1, balanced): %v %v %v", p.Cut, p.Imbalance, p.Weights)
+	}
+	for i := 1; i < 10; i++ {
+		if p.Parts[fmt.Sprintf("0-%d", i)] != p.Parts["0-0"] || p.Parts[fmt.Sprintf("1-%d", i)] != p.Parts["1-0"] {
+			t.Errorf("Error clique split: %v", p.Parts)
+		}
+	}
+
+	// 16x16 grid
+	grid := NewUndirected()
+	id := func(x, y int) string { return fmt.Sprintf("%d,%d", x, y) }
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 16; y++ {
+			grid.Vertex(id(x, y))
+			if x > 0 {
+				grid.Edge(id(x-1, y), id(x, y))
+			}
+			if y > 0 {
+				grid.Edge(id(x, y-1), id(x, y))
+			}
+		}
+	}
+	for _, k := range []int{2, 3, 4, 8} {
+		p, err := MultilevelPartition(grid, k, PartitionOptions{Seed: 1})
+		if err != nil {
+			t.Fatalf("Error partitioning grid: %v", err)
+		}
+		if p.Imbalance > 0.03 {
+			t.Errorf("Error grid imbalance with k=%d: %v %v", k, p.Imbalance, p.Weights)
+		}
+		if limit := []float64{0, 0, 20, 40, 48, 0, 0, 0, 90}[k]; p.Cut > limit {
+			t.Errorf("Error grid cut with k=%d (<= %v): %v", k, limit, p.Cut)
+		}
+		cut := 0.0
+		for _, e := range grid.AllEdges() {
+			a, b := e.Ends()
+			if p.Parts[a.Id()] != p.Parts[b.Id()] {
+				cut++
+			}
+		}
+		if cut != p.Cut {
+			t.Errorf("Error reported cut with k=%d (%v): %v", k, cut, p.Cut)
+		}
+	}
+
+	if _, err := MultilevelPartition(g, 0, PartitionOptions{}); err != ErrPartitionCount {
+		t.Errorf("Error invalid part count: %v", err)
+	}
+	p, _ = MultilevelPartition(g, 1, PartitionOptions{})
+	if p.Cut != 0 || len(p.Weights) != 1 {
+		t.Errorf("Error single part: %v", p)
+	}
+
+	w := NewDirected()
+	w.Edge("a", "b").Set("w", 10)
+	w.Edge("c", "d").Set("w", 10)
+	w.Edge("b", "c").Set("w", 1)
+	w.Edge("d", "a").Set("w", 1)
+	w.Vertex("a").Set("size", 3)
+	p, _ = MultilevelPartition(w, 2, PartitionOptions{Weight: "w", VertexWeight: "size", Balance: 0.5})
+	if p.Cut != 2 || p.Parts["a"] != p.Parts["b"] || p.Parts["c"] != p.Parts["d"] || p.Weights[p.Parts["a"]] != 4 {
+		t.Errorf("Error weighted partition: %v", p)
+	}
+
+	w.Vertex("c").Set("size", -1)
+	if _, err := MultilevelPartition(w, 2, PartitionOptions{VertexWeight: "size"}); err != ErrNegativeWeight {
+		t.Errorf("Error negative vertex weight: %v", err)
+	}
+
+	// fractional weights summed in a fixed order
+	f := NewUndirected()
+	for x := 0; x < 200; x++ {
+		f.Edge(id(x, 0), id((x*7+1)%200, 0)).Set("w", 0.1+float64(x%13)/3)
+		f.Edge(id(x, 0), id((x*31+5)%200, 0)).Set("w", 1/float64(x+3))
+	}
+	first, _ := MultilevelPartition(f, 4, PartitionOptions{Weight: "w", Seed: 7})
+	for i := 0; i < 10; i++ {
+		p, _ := MultilevelPartition(f, 4, PartitionOptions{Weight: "w", Seed: 7})
+		if p.Cut != first.Cut || fmt.Sprint(p.Parts) != fmt.Sprint(first.Parts) {
+			t.Errorf("Error seeded partition not reproducible (%v): %v", first.Cut, p.Cut)
+			break
+		}
+	}
+}
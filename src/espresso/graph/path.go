@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"fmt"
+)
+
+type Path struct {
+	Vertices []*Vertex
+	Edges    []*Edge
+	Weight   float64
+}
+
+func (p *Path) Len() int {
+	return len(p.Edges)
+}
+
+func (p *Path) Source() *Vertex {
+	if len(p.Vertices) == 0 {
+		return nil
+	}
+	return p.Vertices[0]
+}
+
+func (p *Path) Target() *Vertex {
+	if len(p.Vertices) == 0 {
+		return nil
+	}
+	return p.Vertices[len(p.Vertices)-1]
+}
+
+func (p *Path) String() string {
+	if len(p.Vertices) == 0 {
+		return ""
+	}
+	out := fmt.Sprintf("(%s)", p.Vertices[0])
+	for i, e := range p.Edges {
+		if e.directed {
+			out += fmt.Sprintf("-%s->(%s)", e, p.Vertices[i+1])
+		} else {
+			out += fmt.Sprintf("-%s-(%s)", e, p.Vertices[i+1])
+		}
+	}
+	return out
+}
+
+func (p *Path) sameEdges(q *Path, n int) bool {
+	if len(p.Edges) < n || len(q.Edges) < n {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if p.Edges[i] != q.Edges[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func treePath(src, dst *Vertex, dist map[*Vertex]float64, prev map[*Vertex]*Edge) *Path {
+	d, ok := dist[dst]
+	if !ok {
+		return nil
+	}
+	p := &Path{Weight: d}
+	for cur := dst; cur != src; {
+		e := prev[cur]
+		p.Vertices = append(p.Vertices, cur)
+		p.Edges = append(p.Edges, e)
+		cur = e.tail(cur)
+	}
+	p.Vertices = append(p.Vertices, src)
+	reverseVertices(p.Vertices)
+	reverseEdges(p.Edges)
+	return p
+}
@@ -0,0 +1,298 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+)
+
+var ErrNotOutEdge = errors.New("edge is not an out edge of the vertex")
+
+// Folds values reported during a superstep, the first value starts the fold.
+type Aggregator func(a, b interface{}) interface{}
+
+var (
+	SumAggregator Aggregator = func(a, b interface{}) interface{} { return a.(float64) + b.(float64) }
+	MinAggregator Aggregator = func(a, b interface{}) interface{} { return math.Min(a.(float64), b.(float64)) }
+	MaxAggregator Aggregator = func(a, b interface{}) interface{} { return math.Max(a.(float64), b.(float64)) }
+)
+
+// Bulk synchronous vertex-centric computation. Vertices are split across
+// Workers goroutines, GOMAXPROCS when zero; Combiner merges messages to the
+// same vertex and MaxSupersteps bounds the run when positive.
+type Pregel struct {
+	Graph         *Graph
+	Workers       int
+	MaxSupersteps int
+	Init          func(v *Vertex) interface{}
+	Combiner      func(a, b interface{}) interface{}
+	Aggregators   map[string]Aggregator
+}
+
+func NewPregel(g *Graph) *Pregel {
+	return &Pregel{Graph: g, Aggregators: make(map[string]Aggregator)}
+}
+
+type PregelResult struct {
+	Values     map[string]interface{}
+	Aggregated map[string]interface{}
+	Supersteps int
+}
+
+// State of a vertex inside Compute; Value is kept between supersteps.
+// Compute runs concurrently over vertex data that stays read-only during the
+// superstep: Set, SetMap and Unset are applied once every worker is done.
+// Compute may only change its own vertex.
+type PregelVertex struct {
+	*Vertex
+	Value  interface{}
+	index  int
+	halted bool
+	worker *pregelWorker
+}
+
+type envelope struct {
+	to  int
+	msg interface{}
+}
+
+type pregelWorker struct {
+	id         int
+	engine     *pregelRun
+	vertices   []*PregelVertex
+	outbox     [][]envelope
+	aggregated map[string]interface{}
+	writes     []func()
+	err        error
+}
+
+type pregelRun struct {
+	*Pregel
+	superstep  int
+	vertices   []*PregelVertex
+	index      map[*Vertex]int
+	edges      [][]*Edge
+	owner      []int
+	inbox      [][]interface{}
+	workers    []*pregelWorker
+	aggregated map[string]interface{}
+}
+
+func (v *PregelVertex) Superstep() int {
+	return v.worker.engine.superstep
+}
+
+func (v *PregelVertex) OutEdges() []*Edge {
+	return v.worker.engine.edges[v.index]
+}
+
+func (v *PregelVertex) Send(to string, msg interface{}) {
+	u, _ := v.graph.getVertex(to)
+	v.worker.sendTo(u, msg, ErrVertexNotFound)
+}
+
+// Sends to the far end of an out edge.
+func (v *PregelVertex) SendAlong(e *Edge, msg interface{}) {
+	v.worker.sendTo(e.adjacent(v.Vertex), msg, ErrNotOutEdge)
+}
+
+func (v *PregelVertex) SendToNeighbors(msg interface{}) {
+	for _, e := range v.OutEdges() {
+		v.SendAlong(e, msg)
+	}
+}
+
+// Takes effect at the end of the superstep.
+func (v *PregelVertex) Set(key string, value interface{}) *PregelVertex {
+	v.worker.writes = append(v.worker.writes, func() { v.Vertex.Set(key, value) })
+	return v
+}
+
+func (v *PregelVertex) SetMap(values map[string]interface{}) *PregelVertex {
+	copied := make(map[string]interface{}, len(values))
+	for k, value := range values {
+		copied[k] = value
+	}
+	v.worker.writes = append(v.worker.writes, func() { v.Vertex.SetMap(copied) })
+	return v
+}
+
+func (v *PregelVertex) Unset(key string) {
+	v.worker.writes = append(v.worker.writes, func() { v.Vertex.Unset(key) })
+}
+
+// Halts until a message arrives.
+func (v *PregelVertex) VoteToHalt() {
+	v.halted = true
+}
+
+func (v *PregelVertex) Aggregate(name string, value interface{}) {
+	w := v.worker
+	f, ok := w.engine.Aggregators[name]
+	if !ok {
+		return
+	}
+	if old, ok := w.aggregated[name]; ok {
+		value = f(old, value)
+	}
+	w.aggregated[name] = value
+}
+
+// Aggregate of the previous superstep.
+func (v *PregelVertex) Aggregated(name string) (interface{}, bool) {
+	value, ok := v.worker.engine.aggregated[name]
+	return value, ok
+}
+
+// Records err instead of sending when u is not part of the run.
+func (w *pregelWorker) sendTo(u *Vertex, msg interface{}, err error) {
+	to, ok := w.engine.index[u]
+	if !ok {
+		if w.err == nil {
+			w.err = err
+		}
+		return
+	}
+	dst := w.engine.owner[to]
+	w.outbox[dst] = append(w.outbox[dst], envelope{to, msg})
+}
+
+// Runs supersteps until every vertex has halted with no messages in flight.
+func (p *Pregel) Run(compute func(v *PregelVertex, messages []interface{})) (*PregelResult, error) {
+	workers := p.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	vertices := p.Graph.Vertices()
+	if workers > len(vertices) {
+		workers = len(vertices)
+	}
+	r := &pregelRun{
+		Pregel:     p,
+		vertices:   make([]*PregelVertex, len(vertices)),
+		index:      make(map[*Vertex]int, len(vertices)),
+		edges:      make([][]*Edge, len(vertices)),
+		owner:      make([]int, len(vertices)),
+		inbox:      make([][]interface{}, len(vertices)),
+		workers:    make([]*pregelWorker, workers),
+		aggregated: make(map[string]interface{}),
+	}
+	for i := range r.workers {
+		r.workers[i] = &pregelWorker{id: i, engine: r, outbox: make([][]envelope, workers)}
+	}
+	for i, v := range vertices {
+		r.index[v] = i
+		r.edges[i] = v.outEdges()
+		r.owner[i] = i % workers
+		w := r.workers[r.owner[i]]
+		pv := &PregelVertex{Vertex: v, index: i, worker: w}
+		if p.Init != nil {
+			pv.Value = p.Init(v)
+		}
+		r.vertices[i] = pv
+		w.vertices = append(w.vertices, pv)
+	}
+
+	for active := true; active; r.superstep++ {
+		if p.MaxSupersteps > 0 && r.superstep >= p.MaxSupersteps {
+			break
+		}
+		r.parallel(func(w *pregelWorker) {
+			w.aggregated = make(map[string]interface{})
+			for _, v := range w.vertices {
+				messages := r.inbox[v.index]
+				if v.halted && len(messages) == 0 {
+					continue
+				}
+				v.halted = false
+				compute(v, messages)
+			}
+		})
+		for _, w := range r.workers {
+			if w.err != nil {
+				return nil, w.err
+			}
+		}
+		r.write()
+		r.aggregate()
+		active = r.deliver()
+	}
+
+	result := &PregelResult{
+		Values:     make(map[string]interface{}, len(r.vertices)),
+		Aggregated: r.aggregated,
+		Supersteps: r.superstep,
+	}
+	for _, v := range r.vertices {
+		result.Values[v.id] = v.Value
+	}
+	return result, nil
+}
+
+func (r *pregelRun) parallel(f func(w *pregelWorker)) {
+	var wg sync.WaitGroup
+	for _, w := range r.workers {
+		wg.Add(1)
+		go func(w *pregelWorker) {
+			defer wg.Done()
+			f(w)
+		}(w)
+	}
+	wg.Wait()
+}
+
+// Applies the writes of the superstep in worker order; vertex data may be
+// shared through a text index or versioning clock.
+func (r *pregelRun) write() {
+	for _, w := range r.workers {
+		for _, f := range w.writes {
+			f()
+		}
+		w.writes = nil
+	}
+}
+
+func (r *pregelRun) aggregate() {
+	r.aggregated = make(map[string]interface{})
+	for _, w := range r.workers {
+		for name, value := range w.aggregated {
+			if old, ok := r.aggregated[name]; ok {
+				value = r.Aggregators[name](old, value)
+			}
+			r.aggregated[name] = value
+		}
+	}
+}
+
+// Moves outboxes into the next inboxes, each worker filling its own vertices.
+// Reports whether any vertex is still active.
+func (r *pregelRun) deliver() bool {
+	r.parallel(func(dst *pregelWorker) {
+		for _, v := range dst.vertices {
+			r.inbox[v.index] = nil
+		}
+		for _, src := range r.workers {
+			for _, m := range src.outbox[dst.id] {
+				box := r.inbox[m.to]
+				if r.Combiner != nil && len(box) == 1 {
+					box[0] = r.Combiner(box[0], m.msg)
+					continue
+				}
+				r.inbox[m.to] = append(box, m.msg)
+			}
+		}
+	})
+	active := false
+	for _, w := range r.workers {
+		for i := range w.outbox {
+			w.outbox[i] = w.outbox[i][:0]
+		}
+		for _, v := range w.vertices {
+			if !v.halted || len(r.inbox[v.index]) > 0 {
+				active = true
+			}
+		}
+	}
+	return active
+}
@@ -0,0 +1,166 @@
+package graph
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestPregelShortestPaths(t *testing.T) {
+	g := NewDirected()
+	g.Edge("a", "b").Set("w", 4)
+	g.Edge("a", "c").Set("w", 1)
+	g.Edge("c", "b").Set("w", 2)
+	g.Edge("b", "d").Set("w", 1)
+	g.Vertex("e")
+
+	var results []map[string]interface{}
+	for _, workers := range []int{1, 2, 8} {
+		p := NewPregel(g)
+		p.Workers = workers
+		p.Init = func(v *Vertex) interface{} { return math.Inf(1) }
+		p.Combiner = func(a, b interface{}) interface{} { return math.Min(a.(float64), b.(float64)) }
+		r, err := p.Run(func(v *PregelVertex, messages []interface{}) {
+			best := v.Value.(float64)
+			if v.Superstep() == 0 && v.Id() == "a" {
+				best = 0
+			}
+			for _, m := range messages {
+				best = math.Min(best, m.(float64))
+			}
+			if best < v.Value.(float64) {
+				v.Value = best
+				for _, e := range v.OutEdges() {
+					w, _ := e.GetFloat("w")
+					v.SendAlong(e, best+w)
+				}
+			}
+			v.VoteToHalt()
+		})
+		if err != nil {
+			t.Fatalf("Error running pregel: %v", err)
+		}
+		if r.Supersteps != 4 {
+			t.Errorf("Error supersteps with %d workers (4): %d", workers, r.Supersteps)
+		}
+		results = append(results, r.Values)
+	}
+	expected := map[string]interface{}{"a": 0.0, "b": 3.0, "c": 1.0, "d": 4.0, "e": math.Inf(1)}
+	for _, r := range results {
+		if !reflect.DeepEqual(r, expected) {
+			t.Errorf("Error distances: %v", r)
+		}
+	}
+}
+
+func TestPregelPageRank(t *testing.T) {
+	g := NewDirected()
+	g.Edge("a", "b")
+	g.Edge("b", "c")
+	g.Edge("c", "a")
+	g.Edge("c", "b")
+
+	p := NewPregel(g)
+	p.MaxSupersteps = 50
+	p.Aggregators["total"] = SumAggregator
+	p.Aggregators["max"] = MaxAggregator
+	n := float64(g.VertexCount())
+	p.Init = func(v *Vertex) interface{} { return 1 / n }
+	r, err := p.Run(func(v *PregelVertex, messages []interface{}) {
+		if v.Superstep() > 0 {
+			sum := 0.0
+			for _, m := range messages {
+				sum += m.(float64)
+			}
+			v.Value = 0.15/n + 0.85*sum
+		}
+		rank := v.Value.(float64)
+		v.Aggregate("total", rank)
+		v.Aggregate("max", rank)
+		for _, e := range v.OutEdges() {
+			v.SendAlong(e, rank/float64(len(v.OutEdges())))
+		}
+	})
+	if err != nil {
+		t.Fatalf("Error running pregel: %v", err)
+	}
+	if r.Supersteps != 50 {
+		t.Errorf("Error supersteps (50): %d", r.Supersteps)
+	}
+	if total := r.Aggregated["total"].(float64); math.Abs(total-1) > 1e-9 {
+		t.Errorf("Error total rank (1): %f", total)
+	}
+	if b, c := r.Values["b"].(float64), r.Values["c"].(float64); b <= c || r.Aggregated["max"] != b {
+		t.Errorf("Error ranks: %v %v", r.Values, r.Aggregated)
+	}
+
+	_, err = NewPregel(g).Run(func(v *PregelVertex, messages []interface{}) {
+		v.Send("missing", 1)
+	})
+	if err != ErrVertexNotFound {
+		t.Errorf("Error sending to missing vertex: %v", err)
+	}
+
+	_, err = NewPregel(g).Run(func(v *PregelVertex, messages []interface{}) {
+		if v.Id() == "b" {
+			v.SendAlong(g.Edges("a", "b")[0], 1)
+		}
+	})
+	if err != ErrNotOutEdge {
+		t.Errorf("Error sending along an in edge: %v", err)
+	}
+
+	r, _ = NewPregel(New()).Run(func(v *PregelVertex, messages []interface{}) {})
+	if r.Supersteps != 1 || len(r.Values) != 0 {
+		t.Errorf("Error empty graph: %v", r)
+	}
+}
+
+func TestPregelSet(t *testing.T) {
+	g := NewDirected()
+	for i := 0; i < 100; i++ {
+		g.Edge(strconv.Itoa(i), strconv.Itoa((i+1)%100))
+	}
+	ix := g.TextIndex()
+	p := NewPregel(g)
+	p.Workers = 4
+	_, err := p.Run(func(v *PregelVertex, messages []interface{}) {
+		v.Set("name", "vertex "+v.Id())
+		v.VoteToHalt()
+	})
+	if err != nil {
+		t.Fatalf("Error running pregel: %v", err)
+	}
+	if hits := ix.Search("vertex"); len(hits) != 100 {
+		t.Errorf("Error indexed values set in compute (100): %d", len(hits))
+	}
+}
+
+func TestPregelReadNeighbors(t *testing.T) {
+	g := NewDirected()
+	for i := 0; i < 100; i++ {
+		g.Edge(strconv.Itoa(i), strconv.Itoa((i+1)%100))
+		g.Vertex(strconv.Itoa(i)).Set("n", 0)
+	}
+	p := NewPregel(g)
+	p.Workers = 4
+	p.MaxSupersteps = 3
+	_, err := p.Run(func(v *PregelVertex, messages []interface{}) {
+		n, _ := v.GetInt("n")
+		for _, e := range v.OutEdges() {
+			_, to := e.Ends()
+			m, _ := to.GetInt("n")
+			if m != n {
+				t.Errorf("Error neighbor read during superstep %d (%d): %d", v.Superstep(), n, m)
+			}
+		}
+		v.Set("n", n+1)
+	})
+	if err != nil {
+		t.Fatalf("Error running pregel: %v", err)
+	}
+	if n, _ := g.Vertex("0").GetInt("n"); n != 3 {
+		t.Errorf("Error writes applied per superstep (3): %d", n)
+	}
+}
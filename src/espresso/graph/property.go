@@ -0,0 +1,337 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var ErrPropertyNotFound = errors.New("property not found")
+
+type PropertyError struct {
+	Key   string
+	Value interface{}
+	Type  string
+	Err   error
+}
+
+func (e *PropertyError) Error() string {
+	if e.Err == ErrPropertyNotFound {
+		return fmt.Sprintf("property '%s': %s", e.Key, e.Err)
+	}
+	out := fmt.Sprintf("property '%s': cannot convert %#v to %s", e.Key, e.Value, e.Type)
+	if e.Err != nil {
+		out += ": " + e.Err.Error()
+	}
+	return out
+}
+
+func (e *PropertyError) Unwrap() error {
+	return e.Err
+}
+
+type Properties interface {
+	Get(key string) (interface{}, bool)
+}
+
+var TimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+func (d *data) lookup(key, t string) (interface{}, error) {
+	v, ok := d.Get(key)
+	if !ok {
+		return nil, &PropertyError{Key: key, Type: t, Err: ErrPropertyNotFound}
+	}
+	return v, nil
+}
+
+func (d *data) GetString(key string) (string, error) {
+	v, err := d.lookup(key, "string")
+	if err != nil {
+		return "", err
+	}
+	return toString(key, v)
+}
+
+func (d *data) GetInt(key string) (int64, error) {
+	v, err := d.lookup(key, "int")
+	if err != nil {
+		return 0, err
+	}
+	return toInt(key, v)
+}
+
+func (d *data) GetFloat(key string) (float64, error) {
+	v, err := d.lookup(key, "float")
+	if err != nil {
+		return 0, err
+	}
+	return toFloat(key, v)
+}
+
+func (d *data) GetBool(key string) (bool, error) {
+	v, err := d.lookup(key, "bool")
+	if err != nil {
+		return false, err
+	}
+	return toBool(key, v)
+}
+
+func (d *data) GetTime(key string) (time.Time, error) {
+	v, err := d.lookup(key, "time")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return toTime(key, v)
+}
+
+func toString(key string, v interface{}) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case []byte:
+		return string(x), nil
+	case fmt.Stringer:
+		return x.String(), nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", x), nil
+	case float32:
+		return strconv.FormatFloat(float64(x), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64), nil
+	}
+	return "", &PropertyError{Key: key, Value: v, Type: "string"}
+}
+
+func toInt(key string, v interface{}) (int64, error) {
+	switch x := v.(type) {
+	case int:
+		return int64(x), nil
+	case int8:
+		return int64(x), nil
+	case int16:
+		return int64(x), nil
+	case int32:
+		return int64(x), nil
+	case int64:
+		return x, nil
+	case uint:
+		return toIntUnsigned(key, v, uint64(x))
+	case uint8:
+		return int64(x), nil
+	case uint16:
+		return int64(x), nil
+	case uint32:
+		return int64(x), nil
+	case uint64:
+		return toIntUnsigned(key, v, x)
+	case float32:
+		return toIntFloat(key, v, float64(x))
+	case float64:
+		return toIntFloat(key, v, x)
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			return n, nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return 0, &PropertyError{Key: key, Value: v, Type: "int", Err: err}
+		}
+		return toIntFloat(key, v, f)
+	case string:
+		n, err := strconv.ParseInt(x, 10, 64)
+		if err != nil {
+			return 0, &PropertyError{Key: key, Value: v, Type: "int", Err: err}
+		}
+		return n, nil
+	}
+	return 0, &PropertyError{Key: key, Value: v, Type: "int"}
+}
+
+func toIntUnsigned(key string, v interface{}, x uint64) (int64, error) {
+	if x > math.MaxInt64 {
+		return 0, &PropertyError{Key: key, Value: v, Type: "int", Err: strconv.ErrRange}
+	}
+	return int64(x), nil
+}
+
+func toIntFloat(key string, v interface{}, x float64) (int64, error) {
+	if x != math.Trunc(x) || x < math.MinInt64 || x >= math.MaxInt64 {
+		return 0, &PropertyError{Key: key, Value: v, Type: "int"}
+	}
+	return int64(x), nil
+}
+
+func toFloat(key string, v interface{}) (float64, error) {
+	switch x := v.(type) {
+	case float32:
+		return float64(x), nil
+	case float64:
+		return x, nil
+	case int:
+		return float64(x), nil
+	case int8:
+		return float64(x), nil
+	case int16:
+		return float64(x), nil
+	case int32:
+		return float64(x), nil
+	case int64:
+		return float64(x), nil
+	case uint:
+		return float64(x), nil
+	case uint8:
+		return float64(x), nil
+	case uint16:
+		return float64(x), nil
+	case uint32:
+		return float64(x), nil
+	case uint64:
+		return float64(x), nil
+	case json.Number:
+		f, err := x.Float64()
+		if err != nil {
+			return 0, &PropertyError{Key: key, Value: v, Type: "float", Err: err}
+		}
+		return f, nil
+	case string:
+		f, err := strconv.ParseFloat(x, 64)
+		if err != nil {
+			return 0, &PropertyError{Key: key, Value: v, Type: "float", Err: err}
+		}
+		return f, nil
+	}
+	return 0, &PropertyError{Key: key, Value: v, Type: "float"}
+}
+
+func toBool(key string, v interface{}) (bool, error) {
+	switch x := v.(type) {
+	case bool:
+		return x, nil
+	case string:
+		b, err := strconv.ParseBool(x)
+		if err != nil {
+			return false, &PropertyError{Key: key, Value: v, Type: "bool", Err: err}
+		}
+		return b, nil
+	}
+	return false, &PropertyError{Key: key, Value: v, Type: "bool"}
+}
+
+func toTime(key string, v interface{}) (time.Time, error) {
+	switch x := v.(type) {
+	case time.Time:
+		return x, nil
+	case *time.Time:
+		if x != nil {
+			return *x, nil
+		}
+	case string:
+		var err error
+		for _, layout := range TimeLayouts {
+			var t time.Time
+			if t, err = time.Parse(layout, x); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, &PropertyError{Key: key, Value: v, Type: "time", Err: err}
+	}
+	// numbers are unix seconds, fractions kept as nanoseconds
+	f, err := toFloat(key, v)
+	if err != nil {
+		return time.Time{}, &PropertyError{Key: key, Value: v, Type: "time"}
+	}
+	if n, err := toInt(key, v); err == nil {
+		return time.Unix(n, 0).UTC(), nil
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+}
+
+func Prop[T any](p Properties, key string) (T, error) {
+	var zero T
+	v, ok := p.Get(key)
+	if !ok {
+		return zero, &PropertyError{Key: key, Type: fmt.Sprintf("%T", zero), Err: ErrPropertyNotFound}
+	}
+	var out interface{}
+	var err error
+	switch interface{}(zero).(type) {
+	case string:
+		out, err = toString(key, v)
+	case int64:
+		out, err = toInt(key, v)
+	case int:
+		var n int64
+		if n, err = toInt(key, v); err == nil {
+			if n < math.MinInt || n > math.MaxInt {
+				err = &PropertyError{Key: key, Value: v, Type: "int", Err: strconv.ErrRange}
+			}
+			out = int(n)
+		}
+	case int8, int16, int32, uint, uint8, uint16, uint32, uint64:
+		var n int64
+		if n, err = toInt(key, v); err == nil {
+			out, err = sizedInt(key, v, n, reflect.TypeOf(zero))
+		}
+	case float64:
+		out, err = toFloat(key, v)
+	case float32:
+		var f float64
+		if f, err = toFloat(key, v); err == nil {
+			if math.Abs(f) > math.MaxFloat32 && !math.IsInf(f, 0) {
+				err = &PropertyError{Key: key, Value: v, Type: "float32", Err: strconv.ErrRange}
+			}
+			out = float32(f)
+		}
+	case bool:
+		out, err = toBool(key, v)
+	case time.Time:
+		out, err = toTime(key, v)
+	default:
+		t, ok := v.(T)
+		if !ok {
+			return zero, &PropertyError{Key: key, Value: v, Type: fmt.Sprintf("%T", zero)}
+		}
+		return t, nil
+	}
+	if err != nil {
+		return zero, err
+	}
+	return out.(T), nil
+}
+
+// Integer n converted to the sized integer type t, failing when out of range.
+func sizedInt(key string, v interface{}, n int64, t reflect.Type) (interface{}, error) {
+	out := reflect.New(t).Elem()
+	if out.CanInt() && !out.OverflowInt(n) {
+		out.SetInt(n)
+		return out.Interface(), nil
+	}
+	if out.CanUint() && n >= 0 && !out.OverflowUint(uint64(n)) {
+		out.SetUint(uint64(n))
+		return out.Interface(), nil
+	}
+	return nil, &PropertyError{Key: key, Value: v, Type: t.String(), Err: strconv.ErrRange}
+}
+
+func PropOr[T any](p Properties, key string, fallback T) T {
+	v, err := Prop[T](p, key)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,126 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTypedProperties(t *testing.T) {
+	g := New()
+	v := g.Vertex("0").Label("Movie")
+	v.SetMap(map[string]interface{}{
+		"title":  "The Matrix",
+		"year":   "1999-03-31",
+		"rank":   "7",
+		"votes":  1500,
+		"rating": 8.7,
+		"seen":   "true",
+	})
+
+	if s, err := v.GetString("title"); err != nil || s != "The Matrix" {
+		t.Errorf("Error getting string: %#v, %v", s, err)
+	}
+	if s, err := v.GetString("votes"); err != nil || s != "1500" {
+		t.Errorf("Error getting int as string: %#v, %v", s, err)
+	}
+	if n, err := v.GetInt("rank"); err != nil || n != 7 {
+		t.Errorf("Error getting string as int: %d, %v", n, err)
+	}
+	if n, err := v.GetInt("rating"); err == nil {
+		t.Errorf("Error non integral float should not convert to int: %d", n)
+	}
+	if f, err := v.GetFloat("votes"); err != nil || f != 1500 {
+		t.Errorf("Error getting int as float: %f, %v", f, err)
+	}
+	if b, err := v.GetBool("seen"); err != nil || !b {
+		t.Errorf("Error getting string as bool: %t, %v", b, err)
+	}
+	if b, err := v.GetBool("title"); err == nil {
+		t.Errorf("Error title should not convert to bool: %t", b)
+	}
+
+	year, err := v.GetTime("year")
+	if err != nil || !year.Equal(time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Error getting string as time: %v, %v", year, err)
+	}
+
+	if _, err := v.GetString("missing"); !errors.Is(err, ErrPropertyNotFound) {
+		t.Errorf("Error missing property should be not found: %v", err)
+	}
+}
+
+func TestProp(t *testing.T) {
+	g := New()
+	e := g.Edge("3", "0").Label("ACTS_IN")
+	e.Set("role", "Neo").Set("order", int32(1)).Set("since", "2003")
+	e.Set("tags", []string{"lead"})
+
+	if s, err := Prop[string](e, "role"); err != nil || s != "Neo" {
+		t.Errorf("Error getting string prop: %#v, %v", s, err)
+	}
+	if n, err := Prop[int](e, "order"); err != nil || n != 1 {
+		t.Errorf("Error getting int prop: %d, %v", n, err)
+	}
+	if y, err := Prop[time.Time](e, "since"); err != nil || y.Year() != 2003 {
+		t.Errorf("Error getting time prop: %v, %v", y, err)
+	}
+	if tags, err := Prop[[]string](e, "tags"); err != nil || len(tags) != 1 {
+		t.Errorf("Error getting slice prop: %v, %v", tags, err)
+	}
+	if _, err := Prop[map[string]string](e, "tags"); err == nil {
+		t.Errorf("Error slice prop should not assert to map")
+	}
+	if n, err := Prop[int8](e, "order"); err != nil || n != 1 {
+		t.Errorf("Error getting int8 prop: %d, %v", n, err)
+	}
+	if n, err := Prop[uint16](e, "since"); err != nil || n != 2003 {
+		t.Errorf("Error getting string as uint16 prop: %d, %v", n, err)
+	}
+	if n, err := Prop[int8](e, "since"); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Error int8 prop out of range: %d, %v", n, err)
+	}
+	e.Set("weight", 0.5)
+	if f, err := Prop[float32](e, "weight"); err != nil || f != 0.5 {
+		t.Errorf("Error getting float32 prop: %g, %v", f, err)
+	}
+	if f, err := Prop[float32](e, "order"); err != nil || f != 1 {
+		t.Errorf("Error getting int32 as float32 prop: %g, %v", f, err)
+	}
+	if n := PropOr(e, "missing", 42); n != 42 {
+		t.Errorf("Error missing prop should fallback: %d", n)
+	}
+	if s := PropOr(g, "name", "none"); s != "none" {
+		t.Errorf("Error missing graph prop should fallback: %s", s)
+	}
+}
+
+func TestTimeFromJSON(t *testing.T) {
+	var values map[string]interface{}
+	json.Unmarshal([]byte(`{"at":1700000000,"frac":1700000000.5}`), &values)
+	g := New()
+	g.SetMap(values)
+	if at, err := g.GetTime("at"); err != nil || at.Unix() != 1700000000 {
+		t.Errorf("Error getting float64 seconds as time: %v, %v", at, err)
+	}
+	if at, err := g.GetTime("frac"); err != nil || at.Nanosecond() != 5e8 {
+		t.Errorf("Error getting fractional seconds as time: %v, %v", at, err)
+	}
+
+	d := json.NewDecoder(strings.NewReader(`{"at":1700000000,"n":"x"}`))
+	d.UseNumber()
+	d.Decode(&values)
+	g.SetMap(values)
+	if at, err := Prop[time.Time](g, "at"); err != nil || at.Unix() != 1700000000 {
+		t.Errorf("Error getting json.Number as time: %v, %v", at, err)
+	}
+	if n, err := g.GetInt("at"); err != nil || n != 1700000000 {
+		t.Errorf("Error getting json.Number as int: %d, %v", n, err)
+	}
+	if _, err := g.GetTime("n"); err == nil {
+		t.Errorf("Error invalid time should fail")
+	}
+}
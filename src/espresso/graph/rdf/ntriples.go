@@ -0,0 +1,96 @@
+package rdf
+
+import (
+	"bufio"
+	"espresso/graph"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// One triple per line: subject predicate object '.'
+func ReadNTriples(g *graph.Graph, r io.Reader) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	n, b := 0, newBlanks(g)
+	for s.Scan() {
+		n++
+		p := &parser{src: s.Text(), line: n, strict: true, blanks: b}
+		p.skip()
+		if p.eof() {
+			continue
+		}
+		subject, err := p.term(false)
+		if err != nil {
+			return err
+		}
+		predicate, err := p.iri()
+		if err != nil {
+			return err
+		}
+		object, err := p.term(true)
+		if err != nil {
+			return err
+		}
+		if err := p.expect('.'); err != nil {
+			return err
+		}
+		if p.skip(); !p.eof() {
+			return p.errorf("unexpected '%c' after triple", p.peek())
+		}
+		add(g, subject, predicate, object)
+	}
+	if err := s.Err(); err != nil {
+		return &graph.ImportError{Line: n + 1, Err: err}
+	}
+	return nil
+}
+
+// Base resolves ids without a scheme, Prefixes are namespaces for Turtle.
+type Writer struct {
+	Base     string
+	Prefixes map[string]string
+}
+
+func (w *Writer) NTriples(out io.Writer, g *graph.Graph) error {
+	b := bufio.NewWriter(out)
+	for _, t := range triples(g, w.Base) {
+		fmt.Fprintf(b, "%s %s %s .\n", ntriple(&t.s), ntriple(&t.p), ntriple(&t.o))
+	}
+	return b.Flush()
+}
+
+func ntriple(t *term) string {
+	switch t.kind {
+	case blank:
+		return "_:" + t.value
+	case iri:
+		return "<" + escapeIRI(t.value) + ">"
+	}
+	s := `"` + escapeString(t.value) + `"`
+	if t.lang != "" {
+		return s + "@" + t.lang
+	}
+	if t.datatype != "" && t.datatype != XSD+"string" {
+		return s + "^^<" + escapeIRI(t.datatype) + ">"
+	}
+	return s
+}
+
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func escapeString(s string) string {
+	return stringEscaper.Replace(s)
+}
+
+func escapeIRI(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r <= ' ' || strings.ContainsRune("<>\"{}|^`\\", r) {
+			fmt.Fprintf(&b, `\u%04X`, r)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,108 @@
+package rdf
+
+import (
+	"bytes"
+	"errors"
+	"espresso/graph"
+	"strings"
+	"testing"
+)
+
+const movies = `# movies
+<http://example.org/keanu> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.org/Actor> .
+<http://example.org/keanu> <http://example.org/name> "Keanu \"Neo\" Reeves" .
+<http://example.org/keanu> <http://example.org/actedIn> <http://example.org/matrix> .
+<http://example.org/matrix> <http://example.org/title> "Matrix"@en .
+<http://example.org/matrix> <http://example.org/title> "Matrix"@pt .
+<http://example.org/matrix> <http://example.org/released> "1999"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example.org/matrix> <http://example.org/rating> "8.7"^^<http://www.w3.org/2001/XMLSchema#double> .
+_:b0 <http://example.org/actedIn> <http://example.org/matrix> .
+_:b0 <http://example.org/note> "café\ttab" .
+
+<http://example.org/keanu> <http://example.org/actedIn> <http://example.org/matrix> .
+`
+
+func TestReadNTriples(t *testing.T) {
+	g := graph.NewDirected()
+	if err := ReadNTriples(g, strings.NewReader(movies)); err != nil {
+		t.Fatalf("Error reading n-triples: %v", err)
+	}
+	if g.VertexCount() != 3 || g.EdgeCount() != 2 {
+		t.Errorf("Error graph size (3, 2): %d, %d", g.VertexCount(), g.EdgeCount())
+	}
+	keanu := g.Vertex("http://example.org/keanu")
+	if keanu.GetLabel() != "http://example.org/Actor" {
+		t.Errorf("Error label from rdf:type: %s", keanu.GetLabel())
+	}
+	if name, _ := keanu.Get("http://example.org/name"); name != `Keanu "Neo" Reeves` {
+		t.Errorf("Error name: %v", name)
+	}
+	e := g.Edges("http://example.org/keanu", "http://example.org/matrix")
+	if len(e) != 1 || e[0].GetLabel() != "http://example.org/actedIn" {
+		t.Errorf("Error actedIn edge: %v", e)
+	}
+	matrix := g.Vertex("http://example.org/matrix")
+	if year, _ := matrix.Get("http://example.org/released"); year != int64(1999) {
+		t.Errorf("Error released (1999): %#v", year)
+	}
+	if rating, _ := matrix.Get("http://example.org/rating"); rating != 8.7 {
+		t.Errorf("Error rating (8.7): %#v", rating)
+	}
+	titles, _ := matrix.Get("http://example.org/title")
+	if v, ok := titles.([]interface{}); !ok || len(v) != 2 || v[1] != (Literal{"Matrix", "pt", ""}) {
+		t.Errorf("Error titles: %#v", titles)
+	}
+	if note, _ := g.Vertex("_:b0").Get("http://example.org/note"); note != "café\ttab" {
+		t.Errorf("Error escapes: %q", note)
+	}
+
+	for line, bad := range map[int]string{
+		1: `<http://a> <http://b> "c"`,
+		2: "\n<http://a> \"b\" <http://c> .",
+		3: "\n\n<http://a> <http://b> \"c\" . x",
+		4: "\n\n\n<http://a> <http://b> ex:c .",
+	} {
+		err := ReadNTriples(graph.NewDirected(), strings.NewReader(bad))
+		var ie *graph.ImportError
+		if !errors.As(err, &ie) || ie.Line != line {
+			t.Errorf("Error expected failure at line %d: %v", line, err)
+		}
+	}
+}
+
+func TestWriteNTriples(t *testing.T) {
+	g := graph.NewDirected()
+	g.Vertex("keanu").Label("Actor").Set("name", "Keanu\n\"Neo\"").Set("born", 1964)
+	g.Vertex("matrix").Set("title", Literal{"Matrix", "en", ""}).Set("tags", []interface{}{"sci-fi", true})
+	g.Edge("keanu", "matrix").Label("http://example.org/actedIn")
+	g.Edge("matrix", "_:x")
+
+	var out bytes.Buffer
+	w := &Writer{Base: "http://example.org/"}
+	if err := w.NTriples(&out, g); err != nil {
+		t.Fatalf("Error writing n-triples: %v", err)
+	}
+	expected := `<http://example.org/keanu> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://example.org/Actor> .
+<http://example.org/keanu> <http://example.org/actedIn> <http://example.org/matrix> .
+<http://example.org/keanu> <http://example.org/born> "1964"^^<http://www.w3.org/2001/XMLSchema#integer> .
+<http://example.org/keanu> <http://example.org/name> "Keanu\n\"Neo\"" .
+<http://example.org/matrix> <http://example.org/link> _:x .
+<http://example.org/matrix> <http://example.org/tags> "sci-fi" .
+<http://example.org/matrix> <http://example.org/tags> "true"^^<http://www.w3.org/2001/XMLSchema#boolean> .
+<http://example.org/matrix> <http://example.org/title> "Matrix"@en .
+`
+	if out.String() != expected {
+		t.Errorf("Error n-triples:\n%s", out.String())
+	}
+
+	h := graph.NewDirected()
+	if err := ReadNTriples(h, &out); err != nil {
+		t.Fatalf("Error reading written n-triples: %v", err)
+	}
+	if h.VertexCount() != 3 || h.EdgeCount() != 2 {
+		t.Errorf("Error round trip size (3, 2): %d, %d", h.VertexCount(), h.EdgeCount())
+	}
+	if born, _ := h.Vertex("http://example.org/keanu").Get("http://example.org/born"); born != int64(1964) {
+		t.Errorf("Error round trip born: %#v", born)
+	}
+}
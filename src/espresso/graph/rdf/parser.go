@@ -0,0 +1,413 @@
+package rdf
+
+import (
+	"errors"
+	"espresso/graph"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Lexer shared by N-Triples and Turtle, strict accepts only the N-Triples subset.
+type parser struct {
+	src      string
+	pos      int
+	line     int
+	strict   bool
+	base     string
+	prefixes map[string]string
+	blanks   *blanks
+}
+
+// Blank node labels of one read; a label already in the graph from an earlier
+// read, or taken by an anonymous node, is renamed so nodes never merge.
+type blanks struct {
+	g      *graph.Graph
+	labels map[string]string
+	taken  map[string]bool
+	anon   int
+}
+
+func newBlanks(g *graph.Graph) *blanks {
+	return &blanks{g: g, labels: make(map[string]string), taken: make(map[string]bool)}
+}
+
+func (b *blanks) label(label string) string {
+	if l, ok := b.labels[label]; ok {
+		return l
+	}
+	l := b.fresh(label)
+	b.labels[label] = l
+	return l
+}
+
+func (b *blanks) fresh(label string) string {
+	l := label
+	for n := 1; b.taken[l] || b.g.HasVertex("_:"+l); n++ {
+		l = fmt.Sprintf("%s_%d", label, n)
+	}
+	b.taken[l] = true
+	return l
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &graph.ImportError{Line: p.line, Err: fmt.Errorf(format, args...)}
+}
+
+var errEOF = errors.New("unexpected end of input")
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *parser) advance(n int) {
+	p.line += strings.Count(p.src[p.pos:p.pos+n], "\n")
+	p.pos += n
+}
+
+// Whitespace and comments.
+func (p *parser) skip() {
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.advance(1)
+		case c == '#':
+			n := strings.IndexByte(p.src[p.pos:], '\n')
+			if n < 0 {
+				n = len(p.src) - p.pos
+			}
+			p.advance(n)
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) expect(c byte) error {
+	p.skip()
+	if p.eof() {
+		return p.errorf("expected '%c': %v", c, errEOF)
+	}
+	if p.peek() != c {
+		return p.errorf("expected '%c', found '%c'", c, p.peek())
+	}
+	p.advance(1)
+	return nil
+}
+
+func (p *parser) keyword(k string, fold bool) bool {
+	if len(p.src)-p.pos < len(k) {
+		return false
+	}
+	if s := p.src[p.pos : p.pos+len(k)]; s != k && !(fold && strings.EqualFold(s, k)) {
+		return false
+	}
+	end := p.pos + len(k)
+	if end < len(p.src) && (isNameChar(rune(p.src[end])) || p.src[end] == ':') {
+		return false
+	}
+	p.advance(len(k))
+	return true
+}
+
+func isNameChar(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r) || r > 0x7f
+}
+
+func (p *parser) resolve(iri string) string {
+	if p.base == "" || strings.Contains(iri, ":") {
+		return iri
+	}
+	base, err := url.Parse(p.base)
+	if err != nil {
+		return p.base + iri
+	}
+	ref, err := url.Parse(iri)
+	if err != nil {
+		return p.base + iri
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// <iri> with \u escapes.
+func (p *parser) iriRef() (string, error) {
+	if err := p.expect('<'); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated iri: %v", errEOF)
+		}
+		c := p.peek()
+		switch {
+		case c == '>':
+			p.advance(1)
+			return p.resolve(b.String()), nil
+		case c == '\\':
+			r, err := p.escape(false)
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+		case c <= ' ' || strings.IndexByte("<\"{}|^`", c) >= 0:
+			return "", p.errorf("invalid character in iri: %q", c)
+		default:
+			b.WriteByte(c)
+			p.advance(1)
+		}
+	}
+}
+
+// Escape sequence at the current backslash, string escapes only when allowed.
+func (p *parser) escape(str bool) (rune, error) {
+	if p.pos+1 >= len(p.src) {
+		return 0, p.errorf("invalid escape: %v", errEOF)
+	}
+	c := p.src[p.pos+1]
+	n := 0
+	switch c {
+	case 'u':
+		n = 4
+	case 'U':
+		n = 8
+	default:
+		if str {
+			if r, ok := map[byte]rune{'t': '\t', 'b': '\b', 'n': '\n', 'r': '\r', 'f': '\f', '"': '"', '\'': '\'', '\\': '\\'}[c]; ok {
+				p.advance(2)
+				return r, nil
+			}
+		}
+		return 0, p.errorf("invalid escape: \\%c", c)
+	}
+	if p.pos+2+n > len(p.src) {
+		return 0, p.errorf("invalid escape: %v", errEOF)
+	}
+	code, err := strconv.ParseUint(p.src[p.pos+2:p.pos+2+n], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return 0, p.errorf("invalid escape: %s", p.src[p.pos:p.pos+2+n])
+	}
+	p.advance(2 + n)
+	return rune(code), nil
+}
+
+// _:label
+func (p *parser) blankLabel() (string, error) {
+	p.advance(2)
+	start := p.pos
+	for !p.eof() {
+		r, n := utf8.DecodeRuneInString(p.src[p.pos:])
+		if !isNameChar(r) && r != '.' {
+			break
+		}
+		p.advance(n)
+	}
+	label := strings.TrimRight(p.src[start:p.pos], ".")
+	p.pos = start + len(label)
+	if label == "" {
+		return "", p.errorf("empty blank node label")
+	}
+	return label, nil
+}
+
+func (p *parser) newBlank() *term {
+	p.blanks.anon++
+	return &term{kind: blank, value: p.blanks.fresh(fmt.Sprintf("genid%d", p.blanks.anon))}
+}
+
+// prefix:local, the local part may hold '.' but not end with one.
+func (p *parser) prefixedName() (string, error) {
+	start := p.pos
+	var b strings.Builder
+	prefix := ""
+	colon := false
+	end, size := p.pos, 0
+	for !p.eof() {
+		r, n := utf8.DecodeRuneInString(p.src[p.pos:])
+		switch {
+		case r == ':' && !colon:
+			prefix = b.String()
+			b.Reset()
+			colon = true
+			p.advance(n)
+		case r == '\\' && colon && p.pos+1 < len(p.src):
+			b.WriteByte(p.src[p.pos+1])
+			p.advance(2)
+		case isNameChar(r) || r == '.' || (colon && (r == ':' || r == '%')):
+			b.WriteRune(r)
+			p.advance(n)
+			if r == '.' {
+				continue
+			}
+		default:
+			n = 0
+		}
+		if n == 0 {
+			break
+		}
+		end, size = p.pos, b.Len()
+	}
+	if !colon {
+		p.pos = start
+		return "", p.errorf("unexpected %q", p.src[start:min(start+16, len(p.src))])
+	}
+	p.pos = end
+	ns, ok := p.prefixes[prefix]
+	if !ok {
+		return "", p.errorf("unknown prefix: %s:", prefix)
+	}
+	return ns + b.String()[:size], nil
+}
+
+func (p *parser) iri() (*term, error) {
+	p.skip()
+	if p.peek() == '<' {
+		s, err := p.iriRef()
+		return &term{kind: iri, value: s}, err
+	}
+	if p.strict {
+		return nil, p.errorf("expected iri")
+	}
+	s, err := p.prefixedName()
+	return &term{kind: iri, value: s}, err
+}
+
+// Quoted string, Turtle also allows single quotes and long strings.
+func (p *parser) quoted() (string, error) {
+	q := p.src[p.pos : p.pos+1]
+	long := !p.strict && strings.HasPrefix(p.src[p.pos:], q+q+q)
+	if long {
+		q = q + q + q
+	}
+	p.advance(len(q))
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string: %v", errEOF)
+		}
+		if strings.HasPrefix(p.src[p.pos:], q) {
+			p.advance(len(q))
+			return b.String(), nil
+		}
+		switch c := p.peek(); {
+		case c == '\\':
+			r, err := p.escape(true)
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+		case !long && (c == '\n' || c == '\r'):
+			return "", p.errorf("newline in string")
+		default:
+			b.WriteByte(c)
+			p.advance(1)
+		}
+	}
+}
+
+func (p *parser) literal() (*term, error) {
+	s, err := p.quoted()
+	if err != nil {
+		return nil, err
+	}
+	t := &term{kind: literal, value: s}
+	switch {
+	case p.peek() == '@':
+		p.advance(1)
+		start := p.pos
+		for !p.eof() && (isNameChar(rune(p.peek())) && p.peek() != '_') {
+			p.advance(1)
+		}
+		if start == p.pos {
+			return nil, p.errorf("empty language tag")
+		}
+		t.lang = strings.ToLower(p.src[start:p.pos])
+	case strings.HasPrefix(p.src[p.pos:], "^^"):
+		p.advance(2)
+		dt, err := p.iri()
+		if err != nil {
+			return nil, err
+		}
+		t.datatype = dt.value
+	}
+	return t, nil
+}
+
+// Turtle numeric literal: integer, decimal or double.
+func (p *parser) number() (*term, error) {
+	start := p.pos
+	digits := func() int {
+		n := 0
+		for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+			p.advance(1)
+			n++
+		}
+		return n
+	}
+	if c := p.peek(); c == '+' || c == '-' {
+		p.advance(1)
+	}
+	n := digits()
+	datatype := XSD + "integer"
+	if p.peek() == '.' && p.pos+1 < len(p.src) && p.src[p.pos+1] >= '0' && p.src[p.pos+1] <= '9' {
+		p.advance(1)
+		n += digits()
+		datatype = XSD + "decimal"
+	}
+	if c := p.peek(); (c == 'e' || c == 'E') && n > 0 {
+		p.advance(1)
+		if c := p.peek(); c == '+' || c == '-' {
+			p.advance(1)
+		}
+		if digits() == 0 {
+			return nil, p.errorf("invalid number: %s", p.src[start:p.pos])
+		}
+		datatype = XSD + "double"
+	}
+	if n == 0 {
+		return nil, p.errorf("invalid number: %s", p.src[start:p.pos])
+	}
+	return &term{kind: literal, value: p.src[start:p.pos], datatype: datatype}, nil
+}
+
+// Subject or object term; property lists and collections are handled by the Turtle reader.
+func (p *parser) term(object bool) (*term, error) {
+	p.skip()
+	if p.eof() {
+		return nil, p.errorf("expected term: %v", errEOF)
+	}
+	switch c := p.peek(); {
+	case c == '<':
+		return p.iri()
+	case strings.HasPrefix(p.src[p.pos:], "_:"):
+		label, err := p.blankLabel()
+		if err != nil {
+			return nil, err
+		}
+		return &term{kind: blank, value: p.blanks.label(label)}, nil
+	case c == '"' || (c == '\'' && !p.strict):
+		if !object {
+			return nil, p.errorf("literal as subject")
+		}
+		return p.literal()
+	case p.strict:
+		return nil, p.errorf("unexpected '%c'", c)
+	case object && (c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9')):
+		return p.number()
+	case object && p.keyword("true", false):
+		return &term{literal, "true", "", XSD + "boolean"}, nil
+	case object && p.keyword("false", false):
+		return &term{literal, "false", "", XSD + "boolean"}, nil
+	}
+	return p.iri()
+}
@@ -0,0 +1,193 @@
+package rdf
+
+import (
+	"espresso/graph"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	RDF = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	XSD = "http://www.w3.org/2001/XMLSchema#"
+)
+
+// Literal keeps language tagged literals and datatypes with no Go mapping.
+type Literal struct {
+	Value    string
+	Lang     string
+	Datatype string
+}
+
+func (l Literal) String() string {
+	return l.Value
+}
+
+type termKind int
+
+const (
+	iri termKind = iota
+	blank
+	literal
+)
+
+type term struct {
+	kind     termKind
+	value    string
+	lang     string
+	datatype string
+}
+
+// Graph vertex id of an IRI or blank node term.
+func (t *term) id() string {
+	if t.kind == blank {
+		return "_:" + t.value
+	}
+	return t.value
+}
+
+func (t *term) native() interface{} {
+	if t.lang != "" {
+		return Literal{t.value, t.lang, ""}
+	}
+	switch t.datatype {
+	case "", XSD + "string":
+		return t.value
+	case XSD + "integer", XSD + "int", XSD + "long", XSD + "short", XSD + "byte",
+		XSD + "nonNegativeInteger", XSD + "positiveInteger", XSD + "negativeInteger", XSD + "nonPositiveInteger":
+		if n, err := strconv.ParseInt(t.value, 10, 64); err == nil {
+			return n
+		}
+	case XSD + "decimal", XSD + "double", XSD + "float":
+		if f, err := strconv.ParseFloat(t.value, 64); err == nil {
+			return f
+		}
+	case XSD + "boolean":
+		if b, err := strconv.ParseBool(t.value); err == nil {
+			return b
+		}
+	case XSD + "dateTime":
+		if d, err := time.Parse(time.RFC3339Nano, t.value); err == nil {
+			return d
+		}
+	}
+	return Literal{t.value, "", t.datatype}
+}
+
+func literalOf(v interface{}) term {
+	switch x := v.(type) {
+	case Literal:
+		return term{literal, x.Value, x.Lang, x.Datatype}
+	case string:
+		return term{kind: literal, value: x}
+	case bool:
+		return term{literal, strconv.FormatBool(x), "", XSD + "boolean"}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return term{literal, fmt.Sprintf("%d", x), "", XSD + "integer"}
+	case float32:
+		return literalOf(float64(x))
+	case float64:
+		value := strconv.FormatFloat(x, 'e', -1, 64)
+		switch {
+		case math.IsNaN(x):
+			value = "NaN"
+		case math.IsInf(x, 1):
+			value = "INF"
+		case math.IsInf(x, -1):
+			value = "-INF"
+		}
+		return term{literal, value, "", XSD + "double"}
+	case time.Time:
+		return term{literal, x.Format(time.RFC3339Nano), "", XSD + "dateTime"}
+	}
+	return term{kind: literal, value: fmt.Sprint(v)}
+}
+
+// Subjects and IRI objects become vertices, predicates edge labels and
+// literal objects vertex properties; repeated properties collect in a slice.
+// The first rdf:type of a subject is its vertex label, duplicate triples are ignored.
+func add(g *graph.Graph, s, p, o *term) {
+	v := g.Vertex(s.id())
+	if o.kind != literal {
+		if p.value == RDF+"type" && (v.GetLabel() == "" || v.GetLabel() == o.id()) {
+			v.Label(o.id())
+			return
+		}
+		for _, e := range g.Edges(v.Id(), o.id()) {
+			if e.GetLabel() == p.value {
+				return
+			}
+		}
+		g.Edge(v.Id(), o.id()).Label(p.value)
+		return
+	}
+	value := o.native()
+	if old, ok := v.Get(p.value); ok {
+		values, ok := old.([]interface{})
+		if !ok {
+			values = []interface{}{old}
+		}
+		for _, x := range values {
+			if reflect.DeepEqual(x, value) {
+				return
+			}
+		}
+		value = append(values, value)
+	}
+	v.Set(p.value, value)
+}
+
+type triple struct {
+	s, p, o term
+}
+
+// Triples of a graph; ids that are not IRIs or blank nodes are resolved against base,
+// vertex labels become rdf:type and unlabeled edges base "link". Edge properties are
+// not written.
+func triples(g *graph.Graph, base string) []triple {
+	resolve := func(id string) term {
+		if strings.HasPrefix(id, "_:") {
+			return term{kind: blank, value: id[2:]}
+		}
+		if !strings.Contains(id, ":") {
+			id = base + id
+		}
+		return term{kind: iri, value: id}
+	}
+
+	edges := make(map[*graph.Vertex][]*graph.Edge)
+	for _, e := range g.AllEdges() {
+		from, _ := e.Ends()
+		edges[from] = append(edges[from], e)
+	}
+
+	out := make([]triple, 0, g.EdgeCount())
+	for _, v := range g.Vertices() {
+		s := resolve(v.Id())
+		if label := v.GetLabel(); label != "" {
+			out = append(out, triple{s, term{kind: iri, value: RDF + "type"}, resolve(label)})
+		}
+		for _, e := range edges[v] {
+			_, to := e.Ends()
+			p := e.GetLabel()
+			if p == "" {
+				p = "link"
+			}
+			out = append(out, triple{s, resolve(p), resolve(to.Id())})
+		}
+		for _, k := range v.Keys() {
+			value, _ := v.Get(k)
+			values, ok := value.([]interface{})
+			if !ok {
+				values = []interface{}{value}
+			}
+			for _, x := range values {
+				out = append(out, triple{s, resolve(k), literalOf(x)})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,302 @@
+package rdf
+
+import (
+	"bufio"
+	"espresso/graph"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Reads a Turtle document, returns the prefixes it declares.
+func ReadTurtle(g *graph.Graph, r io.Reader) (map[string]string, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: string(src), line: 1, prefixes: make(map[string]string), blanks: newBlanks(g)}
+	for {
+		p.skip()
+		if p.eof() {
+			return p.prefixes, nil
+		}
+		switch {
+		case p.keyword("@prefix", false):
+			err = p.prefix(true)
+		case p.keyword("@base", false):
+			err = p.baseIRI(true)
+		case p.keyword("PREFIX", true):
+			err = p.prefix(false)
+		case p.keyword("BASE", true):
+			err = p.baseIRI(false)
+		default:
+			if err = p.triples(g); err == nil {
+				err = p.expect('.')
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) prefix(dot bool) error {
+	p.skip()
+	start := p.pos
+	for !p.eof() && p.peek() != ':' && (isNameChar(rune(p.peek())) || p.peek() == '.') {
+		p.advance(1)
+	}
+	name := p.src[start:p.pos]
+	if err := p.expect(':'); err != nil {
+		return err
+	}
+	p.skip()
+	ns, err := p.iriRef()
+	if err != nil {
+		return err
+	}
+	p.prefixes[name] = ns
+	if dot {
+		return p.expect('.')
+	}
+	return nil
+}
+
+func (p *parser) baseIRI(dot bool) error {
+	p.skip()
+	iri, err := p.iriRef()
+	if err != nil {
+		return err
+	}
+	p.base = iri
+	if dot {
+		return p.expect('.')
+	}
+	return nil
+}
+
+func (p *parser) triples(g *graph.Graph) error {
+	p.skip()
+	switch p.peek() {
+	case '[':
+		s, err := p.blankNodeList(g)
+		if err != nil {
+			return err
+		}
+		if p.skip(); p.peek() == '.' {
+			return nil
+		}
+		return p.predicateObjects(g, s)
+	case '(':
+		s, err := p.collection(g)
+		if err != nil {
+			return err
+		}
+		return p.predicateObjects(g, s)
+	}
+	s, err := p.term(false)
+	if err != nil {
+		return err
+	}
+	return p.predicateObjects(g, s)
+}
+
+// verb objectList (';' verb objectList)*
+func (p *parser) predicateObjects(g *graph.Graph, s *term) error {
+	for {
+		p.skip()
+		var verb *term
+		if p.keyword("a", false) {
+			verb = &term{kind: iri, value: RDF + "type"}
+		} else {
+			var err error
+			if verb, err = p.iri(); err != nil {
+				return err
+			}
+		}
+		for {
+			o, err := p.object(g)
+			if err != nil {
+				return err
+			}
+			add(g, s, verb, o)
+			if p.skip(); p.peek() != ',' {
+				break
+			}
+			p.advance(1)
+		}
+		if p.peek() != ';' {
+			return nil
+		}
+		for p.peek() == ';' {
+			p.advance(1)
+			p.skip()
+		}
+		if c := p.peek(); c == '.' || c == ']' || p.eof() {
+			return nil
+		}
+	}
+}
+
+func (p *parser) object(g *graph.Graph) (*term, error) {
+	p.skip()
+	switch p.peek() {
+	case '[':
+		return p.blankNodeList(g)
+	case '(':
+		return p.collection(g)
+	}
+	return p.term(true)
+}
+
+// [ predicateObjectList ] as a fresh blank node.
+func (p *parser) blankNodeList(g *graph.Graph) (*term, error) {
+	p.advance(1)
+	b := p.newBlank()
+	if p.skip(); p.peek() == ']' {
+		p.advance(1)
+		return b, nil
+	}
+	if err := p.predicateObjects(g, b); err != nil {
+		return nil, err
+	}
+	return b, p.expect(']')
+}
+
+// ( object* ) as an rdf:first/rdf:rest list.
+func (p *parser) collection(g *graph.Graph) (*term, error) {
+	p.advance(1)
+	head := &term{kind: iri, value: RDF + "nil"}
+	var last *term
+	for {
+		if p.skip(); p.peek() == ')' {
+			p.advance(1)
+			break
+		}
+		o, err := p.object(g)
+		if err != nil {
+			return nil, err
+		}
+		node := p.newBlank()
+		if last == nil {
+			head = node
+		} else {
+			add(g, last, &term{kind: iri, value: RDF + "rest"}, node)
+		}
+		add(g, node, &term{kind: iri, value: RDF + "first"}, o)
+		last = node
+	}
+	if last != nil {
+		add(g, last, &term{kind: iri, value: RDF + "rest"}, &term{kind: iri, value: RDF + "nil"})
+	}
+	return head, nil
+}
+
+// Groups triples by subject and compacts IRIs with Prefixes.
+func (w *Writer) Turtle(out io.Writer, g *graph.Graph) error {
+	b := bufio.NewWriter(out)
+	names := make([]string, 0, len(w.Prefixes))
+	for name := range w.Prefixes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(b, "@prefix %s: <%s> .\n", name, escapeIRI(w.Prefixes[name]))
+	}
+	if len(names) > 0 {
+		b.WriteString("\n")
+	}
+
+	var last *triple
+	ts := triples(g, w.Base)
+	for i := range ts {
+		t := &ts[i]
+		switch {
+		case last == nil:
+		case last.s != t.s:
+			b.WriteString(" .\n\n")
+		case last.p != t.p:
+			b.WriteString(" ;\n    ")
+		default:
+			b.WriteString(", ")
+		}
+		if last == nil || last.s != t.s {
+			b.WriteString(w.turtle(&t.s) + "\n    ")
+		}
+		if last == nil || last.s != t.s || last.p != t.p {
+			if t.p.value == RDF+"type" {
+				b.WriteString("a ")
+			} else {
+				b.WriteString(w.turtle(&t.p) + " ")
+			}
+		}
+		b.WriteString(w.turtle(&t.o))
+		last = t
+	}
+	if last != nil {
+		b.WriteString(" .\n")
+	}
+	return b.Flush()
+}
+
+func (w *Writer) turtle(t *term) string {
+	switch t.kind {
+	case iri:
+		return w.compact(t.value)
+	case blank:
+		return "_:" + t.value
+	}
+	if t.lang == "" {
+		switch t.datatype {
+		case XSD + "integer":
+			if _, err := strconv.ParseInt(t.value, 10, 64); err == nil {
+				return t.value
+			}
+		case XSD + "boolean":
+			if t.value == "true" || t.value == "false" {
+				return t.value
+			}
+		case XSD + "double":
+			if _, err := strconv.ParseFloat(t.value, 64); err == nil && strings.ContainsAny(t.value, "eE") {
+				return t.value
+			}
+		}
+	}
+	s := `"` + escapeString(t.value) + `"`
+	if t.lang != "" {
+		return s + "@" + t.lang
+	}
+	if t.datatype != "" && t.datatype != XSD+"string" {
+		return s + "^^" + w.compact(t.datatype)
+	}
+	return s
+}
+
+// Longest namespace with a valid local name, the full IRI otherwise.
+func (w *Writer) compact(iri string) string {
+	best, local := "", ""
+	found := false
+	for name, ns := range w.Prefixes {
+		if !strings.HasPrefix(iri, ns) || !validLocal(iri[len(ns):]) {
+			continue
+		}
+		if !found || len(ns) > len(w.Prefixes[best]) || len(ns) == len(w.Prefixes[best]) && name < best {
+			best, local, found = name, iri[len(ns):], true
+		}
+	}
+	if found {
+		return best + ":" + local
+	}
+	return "<" + escapeIRI(iri) + ">"
+}
+
+func validLocal(s string) bool {
+	for i, r := range s {
+		if !isNameChar(r) || i == 0 && r == '-' {
+			return false
+		}
+	}
+	return true
+}
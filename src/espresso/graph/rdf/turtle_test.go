@@ -0,0 +1,151 @@
+package rdf
+
+import (
+	"bytes"
+	"errors"
+	"espresso/graph"
+	"strings"
+	"testing"
+)
+
+const people = `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+BASE <http://example.org/people/>
+
+<alice> a foaf:Person ;
+    foaf:name "Alice", 'Ali' ;
+    foaf:age 42 ;
+    ex:height 1.68 ;
+    ex:score 1.5e2 ;
+    ex:active true ;
+    foaf:knows <bob>, [ foaf:name "Carol" ] .
+
+<bob> foaf:name """Robert
+Bob""" ;
+    ex:pets ( ex:rex ex:tom ) ;
+    ex:last.dot ex:end. # trailing dot ends the statement
+`
+
+func TestReadTurtle(t *testing.T) {
+	g := graph.NewDirected()
+	prefixes, err := ReadTurtle(g, strings.NewReader(people))
+	if err != nil {
+		t.Fatalf("Error reading turtle: %v", err)
+	}
+	if len(prefixes) != 2 || prefixes["foaf"] != "http://xmlns.com/foaf/0.1/" {
+		t.Errorf("Error prefixes: %v", prefixes)
+	}
+	alice := g.Vertex("http://example.org/people/alice")
+	if alice.GetLabel() != "http://xmlns.com/foaf/0.1/Person" {
+		t.Errorf("Error label: %s", alice.GetLabel())
+	}
+	names, _ := alice.Get("http://xmlns.com/foaf/0.1/name")
+	if v, ok := names.([]interface{}); !ok || len(v) != 2 || v[0] != "Alice" || v[1] != "Ali" {
+		t.Errorf("Error names: %#v", names)
+	}
+	for k, expected := range map[string]interface{}{
+		"http://xmlns.com/foaf/0.1/age": int64(42),
+		"http://example.org/height":     1.68,
+		"http://example.org/score":      150.0,
+		"http://example.org/active":     true,
+	} {
+		if v, _ := alice.Get(k); v != expected {
+			t.Errorf("Error %s (%v): %#v", k, expected, v)
+		}
+	}
+	if len(g.Edges(alice.Id(), "http://example.org/people/bob")) != 1 {
+		t.Errorf("Error alice knows bob")
+	}
+	if name, _ := g.Vertex("_:genid1").Get("http://xmlns.com/foaf/0.1/name"); name != "Carol" {
+		t.Errorf("Error blank node property list: %v", name)
+	}
+	bob := g.Vertex("http://example.org/people/bob")
+	if name, _ := bob.Get("http://xmlns.com/foaf/0.1/name"); name != "Robert\nBob" {
+		t.Errorf("Error long string: %q", name)
+	}
+	if len(g.Edges("_:genid2", "http://example.org/rex")) != 1 || len(g.Edges("_:genid3", RDF+"nil")) != 1 {
+		t.Errorf("Error collection")
+	}
+	if len(g.Edges(bob.Id(), "http://example.org/end")) != 1 {
+		t.Errorf("Error local name with dot")
+	}
+	if e := g.Edges(bob.Id(), "http://example.org/end"); e == nil || e[0].GetLabel() != "http://example.org/last.dot" {
+		t.Errorf("Error predicate with dot: %v", e)
+	}
+
+	for line, bad := range map[int]string{
+		1: `<a> <b> <c>`,
+		2: "@prefix ex: <http://e/> .\nex:a ex:b nope:c .",
+		3: "<a> <b>\n\n\"unterminated .",
+	} {
+		_, err := ReadTurtle(graph.NewDirected(), strings.NewReader(bad))
+		var ie *graph.ImportError
+		if !errors.As(err, &ie) || ie.Line != line {
+			t.Errorf("Error expected failure at line %d: %v", line, err)
+		}
+	}
+}
+
+func TestReadBlankScope(t *testing.T) {
+	g := graph.NewDirected()
+	doc := "<a> <p> [ <q> <b> ] .\n_:genid1 <q> <c> .\n"
+	if _, err := ReadTurtle(g, strings.NewReader(doc)); err != nil {
+		t.Fatalf("Error reading turtle: %v", err)
+	}
+	if len(g.Edges("_:genid1", "b")) != 1 || len(g.Edges("_:genid1_1", "c")) != 1 {
+		t.Errorf("Error anonymous and labeled blank nodes merged: %v", g)
+	}
+	if err := ReadNTriples(g, strings.NewReader("_:genid1 <q> <d> .\n_:genid1 <q> <e> .\n")); err != nil {
+		t.Fatalf("Error reading ntriples: %v", err)
+	}
+	if v := g.Vertex("_:genid1_2"); len(g.Edges(v.Id(), "d")) != 1 || len(g.Edges(v.Id(), "e")) != 1 {
+		t.Errorf("Error blank node of second read (_:genid1_2): %v", g)
+	}
+	if len(g.Edges("_:genid1", "d")) != 0 {
+		t.Errorf("Error blank nodes of two reads merged")
+	}
+}
+
+func TestWriteTurtle(t *testing.T) {
+	g := graph.NewDirected()
+	g.Vertex("alice").Label("http://xmlns.com/foaf/0.1/Person").Set("http://xmlns.com/foaf/0.1/name", "Alice").Set("age", 42)
+	g.Edge("alice", "bob").Label("http://xmlns.com/foaf/0.1/knows")
+	g.Edge("alice", "carol").Label("http://xmlns.com/foaf/0.1/knows")
+	g.Vertex("bob").Set("score", 1.5).Set("tags", []interface{}{"a", "b"})
+
+	var out bytes.Buffer
+	w := &Writer{Base: "http://example.org/", Prefixes: map[string]string{
+		"ex":   "http://example.org/",
+		"foaf": "http://xmlns.com/foaf/0.1/",
+	}}
+	if err := w.Turtle(&out, g); err != nil {
+		t.Fatalf("Error writing turtle: %v", err)
+	}
+	expected := `@prefix ex: <http://example.org/> .
+@prefix foaf: <http://xmlns.com/foaf/0.1/> .
+
+ex:alice
+    a foaf:Person ;
+    foaf:knows ex:bob, ex:carol ;
+    ex:age 42 ;
+    foaf:name "Alice" .
+
+ex:bob
+    ex:score 1.5e+00 ;
+    ex:tags "a", "b" .
+`
+	if out.String() != expected {
+		t.Errorf("Error turtle:\n%s", out.String())
+	}
+
+	h := graph.NewDirected()
+	if _, err := ReadTurtle(h, &out); err != nil {
+		t.Fatalf("Error reading written turtle: %v", err)
+	}
+	if h.VertexCount() != 3 || h.EdgeCount() != 2 {
+		t.Errorf("Error round trip size (3, 2): %d, %d", h.VertexCount(), h.EdgeCount())
+	}
+	if score, _ := h.Vertex("http://example.org/bob").Get("http://example.org/score"); score != 1.5 {
+		t.Errorf("Error round trip score: %#v", score)
+	}
+}
@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"sort"
+)
+
+// Interval labeling of the condensation (Agrawal, Borgida and Jagadish):
+// strongly connected components are numbered in post order of a spanning
+// forest and each keeps the merged post order intervals it reaches, so a
+// query is one binary search.
+type ReachabilityIndex struct {
+	component map[string]int
+	post      []int
+	intervals [][][2]int
+}
+
+// Tarjan's strongly connected components, iterative; components come out in
+// reverse topological order, sinks first.
+func stronglyConnected(vertices []*Vertex, succ [][]int) []int {
+	n := len(vertices)
+	index := make([]int, n)
+	low := make([]int, n)
+	comp := make([]int, n)
+	onStack := make([]bool, n)
+	for i := range index {
+		index[i], comp[i] = -1, -1
+	}
+	var stack []int
+	counter, count := 0, 0
+	type frame struct{ v, next int }
+	for s := range vertices {
+		if index[s] >= 0 {
+			continue
+		}
+		calls := []frame{{s, 0}}
+		index[s], low[s] = counter, counter
+		counter++
+		stack = append(stack, s)
+		onStack[s] = true
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+			v := top.v
+			if top.next < len(succ[v]) {
+				w := succ[v][top.next]
+				top.next++
+				if index[w] < 0 {
+					index[w], low[w] = counter, counter
+					counter++
+					stack = append(stack, w)
+					onStack[w] = true
+					calls = append(calls, frame{w, 0})
+				} else if onStack[w] {
+					low[v] = min(low[v], index[w])
+				}
+				continue
+			}
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				p := calls[len(calls)-1].v
+				low[p] = min(low[p], low[v])
+			}
+			if low[v] == index[v] {
+				for {
+					w := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[w] = false
+					comp[w] = count
+					if w == v {
+						break
+					}
+				}
+				count++
+			}
+		}
+	}
+	return comp
+}
+
+// Sorts and merges intervals, joining adjacent ones.
+func mergeIntervals(in [][2]int) [][2]int {
+	sort.Slice(in, func(i, j int) bool { return in[i][0] < in[j][0] })
+	out := in[:0]
+	for _, iv := range in {
+		if n := len(out); n > 0 && iv[0] <= out[n-1][1]+1 {
+			out[n-1][1] = max(out[n-1][1], iv[1])
+			continue
+		}
+		out = append(out, iv)
+	}
+	return out
+}
+
+// Follows out edges, so undirected edges work both ways. Labels can grow
+// quadratic in the worst case but stay small on sparse, tree-like graphs.
+func NewReachabilityIndex(g *Graph) *ReachabilityIndex {
+	vertices := g.Vertices()
+	index := make(map[*Vertex]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	succ := make([][]int, len(vertices))
+	for i, v := range vertices {
+		for _, e := range v.outEdges() {
+			succ[i] = append(succ[i], index[e.adjacent(v)])
+		}
+	}
+	comp := stronglyConnected(vertices, succ)
+
+	n := 0
+	r := &ReachabilityIndex{component: make(map[string]int, len(vertices))}
+	for i, v := range vertices {
+		r.component[v.id] = comp[i]
+		n = max(n, comp[i]+1)
+	}
+	dag := make([][]int, n)
+	seen := make(map[[2]int]bool)
+	for v := range succ {
+		for _, w := range succ[v] {
+			if c, d := comp[v], comp[w]; c != d && !seen[[2]int{c, d}] {
+				seen[[2]int{c, d}] = true
+				dag[c] = append(dag[c], d)
+			}
+		}
+	}
+
+	// spanning forest from the sources, highest component first (topological order)
+	r.post = make([]int, n)
+	lowest := make([]int, n)
+	visited := make([]bool, n)
+	clock := 0
+	type frame struct{ c, next int }
+	for s := n - 1; s >= 0; s-- {
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+		lowest[s] = clock
+		calls := []frame{{s, 0}}
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+			if top.next < len(dag[top.c]) {
+				d := dag[top.c][top.next]
+				top.next++
+				if !visited[d] {
+					visited[d] = true
+					lowest[d] = clock
+					calls = append(calls, frame{d, 0})
+				}
+				continue
+			}
+			r.post[top.c] = clock
+			clock++
+			calls = calls[:len(calls)-1]
+		}
+	}
+
+	// sinks first, so successors are labeled before their predecessors
+	r.intervals = make([][][2]int, n)
+	for c := 0; c < n; c++ {
+		in := [][2]int{{lowest[c], r.post[c]}}
+		for _, d := range dag[c] {
+			in = append(in, r.intervals[d]...)
+		}
+		r.intervals[c] = mergeIntervals(in)
+	}
+	return r
+}
+
+// Whether a path leads from a to b; a vertex reaches itself.
+func (r *ReachabilityIndex) CanReach(a, b string) bool {
+	ca, ok1 := r.component[a]
+	cb, ok2 := r.component[b]
+	if !ok1 || !ok2 {
+		return false
+	}
+	p := r.post[cb]
+	in := r.intervals[ca]
+	i := sort.Search(len(in), func(i int) bool { return in[i][1] >= p })
+	return i < len(in) && in[i][0] <= p
+}
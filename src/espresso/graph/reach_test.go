@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestReachabilityIndex(t *testing.T) {
+	g := NewDirected()
+	g.Edge("a", "b")
+	g.Edge("b", "c")
+	g.Edge("c", "a")
+	g.Edge("c", "d")
+	g.Edge("e", "d")
+	g.Vertex("f")
+
+	r := NewReachabilityIndex(g)
+	for _, c := range []struct {
+		from, to string
+		reach    bool
+	}{
+		{"a", "d", true}, {"c", "b", true}, {"d", "a", false}, {"e", "a", false},
+		{"e", "d", true}, {"f", "f", true}, {"f", "a", false}, {"a", "x", false},
+	} {
+		if r.CanReach(c.from, c.to) != c.reach {
+			t.Errorf("Error %s reaches %s: %v", c.from, c.to, !c.reach)
+		}
+	}
+
+	u := NewUndirected()
+	u.Edge("1", "2")
+	u.Edge("3", "2")
+	u.Vertex("4")
+	if r := NewReachabilityIndex(u); !r.CanReach("1", "3") || !r.CanReach("3", "1") || r.CanReach("1", "4") {
+		t.Errorf("Error undirected reachability")
+	}
+
+	// random graphs against breadth-first search
+	rnd := rand.New(rand.NewSource(2))
+	for round := 0; round < 20; round++ {
+		g := NewDirected()
+		for i := 0; i < 30; i++ {
+			g.Vertex(fmt.Sprint(i))
+		}
+		for i := 0; i < 20+round*2; i++ {
+			g.Edge(fmt.Sprint(rnd.Intn(30)), fmt.Sprint(rnd.Intn(30)))
+		}
+		r := NewReachabilityIndex(g)
+		for a := range g.vertices {
+			for b := range g.vertices {
+				if expected := reachableWithout(g, a, b, ""); r.CanReach(a, b) != expected {
+					t.Errorf("Error random graph %d: %s reaches %s %v", round, a, b, !expected)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+)
+
+var ErrSampleSize = errors.New("sample size must be positive")
+
+// Size is the number of vertices to sample (edges for RandomEdgeSample),
+// capped at the graph size. Seeds are the first start vertices of the
+// traversal samples, random vertices follow when they run dry. Burn is the
+// forest fire forward burning probability (0.7 when zero) and Restart the
+// random walk chance of flying back to its start (0.15 when zero).
+type SampleOptions struct {
+	Size    int
+	Seeds   []string
+	Burn    float64
+	Restart float64
+	Seed    int64
+}
+
+type sampler struct {
+	rand     *rand.Rand
+	vertices []*Vertex
+	order    []int
+	next     int
+	seeds    []*Vertex
+	keep     map[*Vertex]bool
+	size     int
+}
+
+func newSampler(g *Graph, opt SampleOptions) (*sampler, error) {
+	if opt.Size <= 0 {
+		return nil, ErrSampleSize
+	}
+	s := &sampler{
+		rand:     rand.New(rand.NewSource(opt.Seed)),
+		vertices: g.Vertices(),
+		keep:     make(map[*Vertex]bool),
+	}
+	for _, id := range opt.Seeds {
+		v, ok := g.getVertex(id)
+		if !ok {
+			return nil, ErrVertexNotFound
+		}
+		s.seeds = append(s.seeds, v)
+	}
+	s.order = s.rand.Perm(len(s.vertices))
+	s.size = min(opt.Size, len(s.vertices))
+	return s, nil
+}
+
+func (s *sampler) full() bool {
+	return len(s.keep) >= s.size
+}
+
+// Next seed or random vertex not sampled yet, nil when there is none.
+func (s *sampler) start() *Vertex {
+	for len(s.seeds) > 0 {
+		v := s.seeds[0]
+		s.seeds = s.seeds[1:]
+		if !s.keep[v] {
+			return v
+		}
+	}
+	for ; s.next < len(s.order); s.next++ {
+		if v := s.vertices[s.order[s.next]]; !s.keep[v] {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *sampler) take(v *Vertex) {
+	if !s.full() {
+		s.keep[v] = true
+	}
+}
+
+// Out neighbors not sampled yet, in edge order.
+func (s *sampler) unvisited(v *Vertex) []*Vertex {
+	var out []*Vertex
+	seen := make(map[*Vertex]bool)
+	for _, e := range v.outEdges() {
+		if u := e.adjacent(v); !s.keep[u] && !seen[u] {
+			seen[u] = true
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// Subgraph induced by Size vertices chosen uniformly at random.
+func RandomNodeSample(g *Graph, opt SampleOptions) (*Graph, error) {
+	s, err := newSampler(g, opt)
+	if err != nil {
+		return nil, err
+	}
+	for !s.full() {
+		s.take(s.start())
+	}
+	return g.induced(s.keep), nil
+}
+
+// Size edges chosen uniformly at random with their end vertices; other edges
+// between those vertices are left out.
+func RandomEdgeSample(g *Graph, opt SampleOptions) (*Graph, error) {
+	if opt.Size <= 0 {
+		return nil, ErrSampleSize
+	}
+	r := rand.New(rand.NewSource(opt.Seed))
+	edges := g.AllEdges()
+	chosen := make(map[int]bool)
+	for _, i := range r.Perm(len(edges))[:min(opt.Size, len(edges))] {
+		chosen[i] = true
+	}
+	c := &Graph{_type: g._type}
+	c.SetMap(g.data.values)
+	for i, e := range edges {
+		if chosen[i] {
+			from, to := e.Ends()
+			c.copyVertex(from)
+			c.copyVertex(to)
+			c.copyKind(e).Label(e.label).SetMap(e.data.values)
+		}
+	}
+	return c, nil
+}
+
+// Breadth-first search from the seeds in either direction until Size
+// vertices are reached, restarting from a random vertex when a component is
+// exhausted; returns the induced subgraph.
+func SnowballSample(g *Graph, opt SampleOptions) (*Graph, error) {
+	s, err := newSampler(g, opt)
+	if err != nil {
+		return nil, err
+	}
+	for !s.full() {
+		queue := []*Vertex{s.start()}
+		s.take(queue[0])
+		for len(queue) > 0 && !s.full() {
+			v := queue[0]
+			queue = queue[1:]
+			for _, u := range v.neighbors() {
+				if !s.keep[u] && !s.full() {
+					s.take(u)
+					queue = append(queue, u)
+				}
+			}
+		}
+	}
+	return g.induced(s.keep), nil
+}
+
+// Leskovec-Faloutsos forest fire: each burning vertex sets fire to a
+// geometric number of its unburnt out neighbors, mean Burn/(1-Burn), and a
+// new fire starts when one dies out. Returns the induced subgraph.
+func ForestFireSample(g *Graph, opt SampleOptions) (*Graph, error) {
+	s, err := newSampler(g, opt)
+	if err != nil {
+		return nil, err
+	}
+	burn := opt.Burn
+	if burn <= 0 {
+		burn = 0.7
+	}
+	for !s.full() {
+		queue := []*Vertex{s.start()}
+		s.take(queue[0])
+		for len(queue) > 0 && !s.full() {
+			v := queue[0]
+			queue = queue[1:]
+			neighbors := s.unvisited(v)
+			s.rand.Shuffle(len(neighbors), func(i, j int) { neighbors[i], neighbors[j] = neighbors[j], neighbors[i] })
+			n := 0
+			for n < len(neighbors) && s.rand.Float64() < burn {
+				n++
+			}
+			for _, u := range neighbors[:n] {
+				s.take(u)
+				queue = append(queue, u)
+			}
+		}
+	}
+	return g.induced(s.keep), nil
+}
+
+// Random walk along out edges that flies back to its start with probability
+// Restart and at dead ends; it moves to a new start when it finds no new
+// vertex in 100 * Size steps. Returns the subgraph induced by the visited vertices.
+func RandomWalkSample(g *Graph, opt SampleOptions) (*Graph, error) {
+	s, err := newSampler(g, opt)
+	if err != nil {
+		return nil, err
+	}
+	restart := opt.Restart
+	if restart <= 0 {
+		restart = 0.15
+	}
+	for !s.full() {
+		start := s.start()
+		s.take(start)
+		v := start
+		for stale := 0; stale < 100*s.size && !s.full(); stale++ {
+			edges := v.outEdges()
+			if len(edges) == 0 || s.rand.Float64() < restart {
+				v = start
+				continue
+			}
+			v = edges[s.rand.Intn(len(edges))].adjacent(v)
+			if !s.keep[v] {
+				s.take(v)
+				stale = 0
+			}
+		}
+	}
+	return g.induced(s.keep), nil
+}
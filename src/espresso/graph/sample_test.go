@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func sampleGraph() *Graph {
+	g := NewDirected()
+	g.Set("name", "grid")
+	id := func(x, y int) string { return fmt.Sprintf("%d,%d", x, y) }
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			g.Vertex(id(x, y)).Label("Cell").Set("x", x).Set("y", y)
+			if x > 0 {
+				g.Edge(id(x-1, y), id(x, y)).Label("RIGHT").Set("w", x)
+			}
+			if y > 0 {
+				g.Edge(id(x, y-1), id(x, y)).Label("DOWN").Set("w", y)
+			}
+		}
+	}
+	return g
+}
+
+// Every vertex and edge of the sample is a copy of one in g.
+func checkSample(t *testing.T, name string, g, s *Graph) {
+	if s.Type() != g.Type() || s.data.values["name"] != "grid" {
+		t.Errorf("Error %s sample graph: %v %v", name, s.Type(), s.data.values)
+	}
+	for _, v := range s.Vertices() {
+		o, ok := g.getVertex(v.Id())
+		if !ok || v.GetLabel() != o.GetLabel() || v.values["x"] != o.values["x"] || v.values["y"] != o.values["y"] {
+			t.Errorf("Error %s sample vertex: %v", name, v)
+		}
+	}
+	for _, e := range s.AllEdges() {
+		o := g.EdgeById(e.Id())
+		if o == nil || e.GetLabel() != o.GetLabel() || e.values["w"] != o.values["w"] || e.String() != o.String() {
+			t.Errorf("Error %s sample edge: %v", name, e)
+		}
+	}
+}
+
+func TestSamples(t *testing.T) {
+	g := sampleGraph()
+	samplers := map[string]func(*Graph, SampleOptions) (*Graph, error){
+		"node":        RandomNodeSample,
+		"snowball":    SnowballSample,
+		"forest fire": ForestFireSample,
+		"random walk": RandomWalkSample,
+	}
+	for name, sample := range samplers {
+		s, err := sample(g, SampleOptions{Size: 30, Seed: 7})
+		if err != nil {
+			t.Fatalf("Error %s sample: %v", name, err)
+		}
+		if s.VertexCount() != 30 {
+			t.Errorf("Error %s sample size: %v", name, s.VertexCount())
+		}
+		checkSample(t, name, g, s)
+		// induced: every edge of g between sampled vertices is kept
+		for _, e := range g.AllEdges() {
+			from, to := e.Ends()
+			_, ok1 := s.getVertex(from.Id())
+			_, ok2 := s.getVertex(to.Id())
+			if ok1 && ok2 && s.EdgeById(e.Id()) == nil {
+				t.Errorf("Error %s sample missing edge: %v", name, e)
+			}
+		}
+		if again, _ := sample(g, SampleOptions{Size: 30, Seed: 7}); fmt.Sprint(vertexIds(again.Vertices())) != fmt.Sprint(vertexIds(s.Vertices())) {
+			t.Errorf("Error %s sample not reproducible", name)
+		}
+		if all, _ := sample(g, SampleOptions{Size: 1000}); all.VertexCount() != 100 || all.EdgeCount() != g.EdgeCount() {
+			t.Errorf("Error %s sample of the whole graph: %v %v", name, all.VertexCount(), all.EdgeCount())
+		}
+		if _, err := sample(g, SampleOptions{}); err != ErrSampleSize {
+			t.Errorf("Error %s sample without size: %v", name, err)
+		}
+		if _, err := sample(g, SampleOptions{Size: 3, Seeds: []string{"x"}}); err != ErrVertexNotFound {
+			t.Errorf("Error %s sample unknown seed: %v", name, err)
+		}
+	}
+
+	// traversal samples stay connected around the seed
+	for _, sample := range []func(*Graph, SampleOptions) (*Graph, error){SnowballSample, RandomWalkSample} {
+		s, _ := sample(g, SampleOptions{Size: 10, Seeds: []string{"0,0"}, Seed: 3})
+		if _, ok := s.getVertex("0,0"); !ok || Stats(s).Components != 1 {
+			t.Errorf("Error sample from seed: %v", s.Vertices())
+		}
+	}
+	if s, _ := SnowballSample(g, SampleOptions{Size: 3, Seeds: []string{"5,5"}}); fmt.Sprint(vertexIds(s.Vertices())) != "[4,5 5,4 5,5]" {
+		t.Errorf("Error snowball order: %v", s.Vertices())
+	}
+
+	s, err := RandomEdgeSample(g, SampleOptions{Size: 25, Seed: 1})
+	if err != nil || s.EdgeCount() != 25 {
+		t.Errorf("Error edge sample: %v %v", err, s)
+	}
+	checkSample(t, "edge", g, s)
+}
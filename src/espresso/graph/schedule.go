@@ -0,0 +1,164 @@
+package graph
+
+import (
+	"math"
+)
+
+// Vertex properties set by CriticalPath.
+const (
+	ScheduleEarliestStart  = "earliest_start"
+	ScheduleEarliestFinish = "earliest_finish"
+	ScheduleLatestStart    = "latest_start"
+	ScheduleLatestFinish   = "latest_finish"
+	ScheduleSlack          = "slack"
+	ScheduleCritical       = "critical"
+)
+
+type Schedule struct {
+	Length   float64
+	Critical *Path
+}
+
+func durations(g *Graph, duration string) (map[*Vertex]float64, error) {
+	out := make(map[*Vertex]float64, len(g.vertices))
+	for _, v := range g.vertices {
+		if _, ok := v.Get(duration); !ok {
+			continue
+		}
+		d, err := v.GetFloat(duration)
+		if err != nil {
+			return nil, err
+		}
+		if d < 0 {
+			return nil, ErrNegativeWeight
+		}
+		out[v] = d
+	}
+	return out, nil
+}
+
+// Critical path method; vertices without duration are milestones of length 0.
+func CriticalPath(g *Graph, duration string) (*Schedule, error) {
+	order, err := TopologicalSort(g)
+	if err != nil {
+		return nil, err
+	}
+	d, err := durations(g, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	es := make(map[*Vertex]float64, len(order))
+	length := 0.0
+	for _, v := range order {
+		for _, e := range v.inEdges() {
+			if u := e.tail(v); es[u]+d[u] > es[v] {
+				es[v] = es[u] + d[u]
+			}
+		}
+		length = math.Max(length, es[v]+d[v])
+	}
+
+	lf := make(map[*Vertex]float64, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		v := order[i]
+		lf[v] = length
+		for _, e := range v.outEdges() {
+			if u := e.adjacent(v); lf[u]-d[u] < lf[v] {
+				lf[v] = lf[u] - d[u]
+			}
+		}
+	}
+
+	const epsilon = 1e-9
+	critical := func(v *Vertex) bool {
+		return lf[v]-d[v]-es[v] < epsilon
+	}
+	for _, v := range order {
+		slack := lf[v] - d[v] - es[v]
+		v.SetMap(map[string]interface{}{
+			ScheduleEarliestStart:  es[v],
+			ScheduleEarliestFinish: es[v] + d[v],
+			ScheduleLatestStart:    lf[v] - d[v],
+			ScheduleLatestFinish:   lf[v],
+			ScheduleSlack:          slack,
+			ScheduleCritical:       critical(v),
+		})
+	}
+
+	s := &Schedule{Length: length}
+	if len(order) == 0 {
+		return s, nil
+	}
+	var cur *Vertex
+	for _, v := range order {
+		if es[v] == 0 && critical(v) {
+			cur = v
+			break
+		}
+	}
+	s.Critical = &Path{Vertices: []*Vertex{cur}, Weight: length}
+	for math.Abs(es[cur]+d[cur]-length) > epsilon {
+		var next *Edge
+		for _, e := range cur.outEdges() {
+			u := e.adjacent(cur)
+			if critical(u) && math.Abs(es[u]-es[cur]-d[cur]) < epsilon {
+				next = e
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		cur = next.adjacent(cur)
+		s.Critical.Vertices = append(s.Critical.Vertices, cur)
+		s.Critical.Edges = append(s.Critical.Edges, next)
+	}
+	return s, nil
+}
+
+// Heaviest path by edge weight in a directed acyclic graph.
+func LongestPath(g *Graph, weight string) (*Path, error) {
+	order, err := TopologicalSort(g)
+	if err != nil {
+		return nil, err
+	}
+	weights, err := edgeWeights(g, weight)
+	if err != nil {
+		return nil, err
+	}
+	if len(order) == 0 {
+		return nil, nil
+	}
+
+	dist := make(map[*Vertex]float64, len(order))
+	prev := make(map[*Vertex]*Edge, len(order))
+	end := order[0]
+	for _, v := range order {
+		for _, e := range v.inEdges() {
+			u := e.tail(v)
+			// a path may also start at v, so only heavier in-edges extend one
+			if w := dist[u] + weights[e]; w > dist[v] {
+				dist[v] = w
+				prev[v] = e
+			}
+		}
+		if dist[v] > dist[end] {
+			end = v
+		}
+	}
+
+	p := &Path{Weight: dist[end]}
+	for cur := end; cur != nil; {
+		p.Vertices = append(p.Vertices, cur)
+		e := prev[cur]
+		if e == nil {
+			break
+		}
+		p.Edges = append(p.Edges, e)
+		cur = e.tail(cur)
+	}
+	reverseVertices(p.Vertices)
+	reverseEdges(p.Edges)
+	return p, nil
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"testing"
+)
+
+func TestCriticalPath(t *testing.T) {
+	g := NewDirected()
+	g.Vertex("start")
+	g.Vertex("design").Set("duration", 3)
+	g.Vertex("backend").Set("duration", 5)
+	g.Vertex("frontend").Set("duration", "2")
+	g.Vertex("docs").Set("duration", 1)
+	g.Vertex("release").Set("duration", 1)
+	g.Edge("start", "design")
+	g.Edge("design", "backend")
+	g.Edge("design", "frontend")
+	g.Edge("start", "docs")
+	g.Edge("backend", "release")
+	g.Edge("frontend", "release")
+	g.Edge("docs", "release")
+
+	s, err := CriticalPath(g, "duration")
+	if err != nil {
+		t.Fatalf("Error critical path: %v", err)
+	}
+	if s.Length != 9 {
+		t.Errorf("Error project length (9): %g", s.Length)
+	}
+	if ids := vertexIds(s.Critical.Vertices); len(ids) != 4 || ids[1] != "design" || ids[2] != "backend" || ids[3] != "release" {
+		t.Errorf("Error critical path: %v", ids)
+	}
+
+	frontend := g.Vertex("frontend")
+	if es, _ := frontend.GetFloat(ScheduleEarliestStart); es != 3 {
+		t.Errorf("Error frontend earliest start (3): %g", es)
+	}
+	if ls, _ := frontend.GetFloat(ScheduleLatestStart); ls != 6 {
+		t.Errorf("Error frontend latest start (6): %g", ls)
+	}
+	if slack, _ := frontend.GetFloat(ScheduleSlack); slack != 3 {
+		t.Errorf("Error frontend slack (3): %g", slack)
+	}
+	if critical, _ := frontend.GetBool(ScheduleCritical); critical {
+		t.Errorf("Error frontend should not be critical")
+	}
+	if slack, _ := g.Vertex("docs").GetFloat(ScheduleSlack); slack != 7 {
+		t.Errorf("Error docs slack (7): %g", slack)
+	}
+
+	g.Edge("release", "design")
+	if _, err := CriticalPath(g, "duration"); err != ErrCycle {
+		t.Errorf("Error cyclic schedule: %v", err)
+	}
+}
+
+func TestLongestPath(t *testing.T) {
+	g := NewDirected()
+	g.Edge("a", "b").Set("w", 1)
+	g.Edge("b", "c").Set("w", 1)
+	g.Edge("a", "c").Set("w", 5)
+	g.Edge("c", "d").Set("w", 1)
+
+	p, err := LongestPath(g, "w")
+	if err != nil {
+		t.Fatalf("Error longest path: %v", err)
+	}
+	if p.Weight != 6 || p.String() != "(a)-[{w:5}]->(c)-[{w:1}]->(d)" {
+		t.Errorf("Error longest path (6): %g %s", p.Weight, p)
+	}
+
+	p, _ = LongestPath(g, "")
+	if p.Len() != 3 {
+		t.Errorf("Error unweighted longest path (3): %s", p)
+	}
+
+	n := NewDirected()
+	n.Edge("a", "b").Set("w", -5)
+	n.Edge("b", "c").Set("w", 2)
+	n.Edge("c", "d").Set("w", 3)
+	p, _ = LongestPath(n, "w")
+	if p.Weight != 5 || p.String() != "(b)-[{w:2}]->(c)-[{w:3}]->(d)" {
+		t.Errorf("Error longest path should skip negative edge (5): %g %s", p.Weight, p)
+	}
+}
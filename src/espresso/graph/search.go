@@ -0,0 +1,303 @@
+package graph
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type Ranking int
+
+const (
+	BM25 Ranking = iota
+	TFIDF
+)
+
+// Inverted index over the string properties of vertices and edges, kept up
+// to date by Set, Unset and Remove. K1 and B tune BM25.
+type TextIndex struct {
+	Ranking Ranking
+	K1, B   float64
+	graph   *Graph
+	// term, document, property key and token positions
+	postings map[string]map[*document]map[string][]int
+	docs     int
+	length   int
+	// sorted vocabulary, kept on updates so searches only read
+	terms []string
+}
+
+// Indexed tokens of a vertex or an edge.
+type document struct {
+	index  *TextIndex
+	vertex *Vertex
+	edge   *Edge
+	fields map[string][]string
+	length int
+}
+
+type Hit struct {
+	Vertex *Vertex
+	Edge   *Edge
+	Score  float64
+}
+
+// Lower case runs of letters and digits.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Tokens of string values; elements of arrays are separated by an empty
+// token so phrases do not match across them.
+func valueTokens(value interface{}) []string {
+	var out []string
+	add := func(s string) {
+		if len(out) > 0 {
+			out = append(out, "")
+		}
+		out = append(out, tokenize(s)...)
+	}
+	switch v := value.(type) {
+	case string:
+		add(v)
+	case []string:
+		for _, s := range v {
+			add(s)
+		}
+	case []interface{}:
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				add(s)
+			}
+		}
+	}
+	return out
+}
+
+// Creates the index on first use, indexing the current vertices and edges.
+func (g *Graph) TextIndex() *TextIndex {
+	if g.index != nil {
+		return g.index
+	}
+	ix := &TextIndex{K1: 1.2, B: 0.75}
+	g.index = ix
+	ix.reset(g)
+	return ix
+}
+
+// Empties the index and indexes the vertices and edges of g.
+func (ix *TextIndex) reset(g *Graph) {
+	ix.graph = g
+	ix.postings = make(map[string]map[*document]map[string][]int)
+	ix.docs, ix.length, ix.terms = 0, 0, nil
+	for _, v := range g.vertices {
+		ix.attach(&v.data, v, nil)
+	}
+	for _, e := range g.edgeIds {
+		ix.attach(&e.data, nil, e)
+	}
+}
+
+func (ix *TextIndex) attach(d *data, v *Vertex, e *Edge) {
+	doc := &document{index: ix, vertex: v, edge: e, fields: make(map[string][]string)}
+	d.doc = doc
+	ix.docs++
+	for k, value := range d.values {
+		doc.set(k, value)
+	}
+}
+
+func (doc *document) set(key string, value interface{}) {
+	doc.unset(key)
+	tokens := valueTokens(value)
+	if len(tokens) == 0 {
+		return
+	}
+	ix := doc.index
+	doc.fields[key] = tokens
+	for pos, t := range tokens {
+		if t == "" {
+			continue
+		}
+		docs, ok := ix.postings[t]
+		if !ok {
+			docs = make(map[*document]map[string][]int)
+			ix.postings[t] = docs
+			i := sort.SearchStrings(ix.terms, t)
+			ix.terms = append(ix.terms, "")
+			copy(ix.terms[i+1:], ix.terms[i:])
+			ix.terms[i] = t
+		}
+		if docs[doc] == nil {
+			docs[doc] = make(map[string][]int)
+		}
+		docs[doc][key] = append(docs[doc][key], pos)
+		doc.length++
+		ix.length++
+	}
+}
+
+func (doc *document) unset(key string) {
+	ix := doc.index
+	for _, t := range doc.fields[key] {
+		docs, ok := ix.postings[t]
+		if !ok || docs[doc] == nil {
+			continue
+		}
+		if n := len(docs[doc][key]); n > 0 {
+			doc.length -= n
+			ix.length -= n
+		}
+		delete(docs[doc], key)
+		if len(docs[doc]) == 0 {
+			delete(docs, doc)
+		}
+		if len(docs) == 0 {
+			delete(ix.postings, t)
+			i := sort.SearchStrings(ix.terms, t)
+			ix.terms = append(ix.terms[:i], ix.terms[i+1:]...)
+		}
+	}
+	delete(doc.fields, key)
+}
+
+func (doc *document) remove() {
+	for k := range doc.fields {
+		doc.unset(k)
+	}
+	doc.index.docs--
+}
+
+func (doc *document) id() string {
+	if doc.vertex != nil {
+		return doc.vertex.id
+	}
+	return doc.edge.id
+}
+
+// Terms starting with prefix, from the sorted vocabulary.
+func (ix *TextIndex) expand(prefix string) []string {
+	i := sort.SearchStrings(ix.terms, prefix)
+	j := i
+	for j < len(ix.terms) && strings.HasPrefix(ix.terms[j], prefix) {
+		j++
+	}
+	return ix.terms[i:j]
+}
+
+// A term, a prefix or a phrase of consecutive terms in one property.
+type clause struct {
+	terms  []string
+	prefix bool
+}
+
+func parseQuery(query string) []clause {
+	var clauses []clause
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 {
+			if terms := tokenize(part); len(terms) > 0 {
+				clauses = append(clauses, clause{terms: terms})
+			}
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			terms := tokenize(word)
+			for j, t := range terms {
+				last := j == len(terms)-1
+				clauses = append(clauses, clause{terms: []string{t}, prefix: last && strings.HasSuffix(word, "*")})
+			}
+		}
+	}
+	return clauses
+}
+
+// Term frequency of a clause in every matching document.
+func (ix *TextIndex) match(c clause) map[*document]float64 {
+	out := make(map[*document]float64)
+	if c.prefix {
+		for _, t := range ix.expand(c.terms[0]) {
+			for doc, fields := range ix.postings[t] {
+				for _, positions := range fields {
+					out[doc] += float64(len(positions))
+				}
+			}
+		}
+		return out
+	}
+	for doc, fields := range ix.postings[c.terms[0]] {
+		for key, positions := range fields {
+			for _, p := range positions {
+				if ix.phraseAt(doc, key, c.terms, p) {
+					out[doc]++
+				}
+			}
+		}
+	}
+	return out
+}
+
+func (ix *TextIndex) phraseAt(doc *document, key string, terms []string, p int) bool {
+	for i, t := range terms[1:] {
+		positions := ix.postings[t][doc][key]
+		j := sort.SearchInts(positions, p+i+1)
+		if j == len(positions) || positions[j] != p+i+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (ix *TextIndex) score(tf, df float64, doc *document) float64 {
+	n := float64(ix.docs)
+	if ix.Ranking == TFIDF {
+		return (1 + math.Log(tf)) * math.Log(1+n/df)
+	}
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+	avg := float64(ix.length) / n
+	norm := ix.K1 * (1 - ix.B + ix.B*float64(doc.length)/avg)
+	return idf * tf * (ix.K1 + 1) / (tf + norm)
+}
+
+// Vertices and edges matching every word of the query, best first. Words
+// ending in * match as prefixes and quoted words as phrases.
+func (ix *TextIndex) Search(query string) []Hit {
+	clauses := parseQuery(query)
+	if len(clauses) == 0 {
+		return nil
+	}
+	var scores map[*document]float64
+	for _, c := range clauses {
+		matches := ix.match(c)
+		df := float64(len(matches))
+		next := make(map[*document]float64, len(matches))
+		for doc, tf := range matches {
+			if score, ok := scores[doc]; ok || scores == nil {
+				next[doc] = score + ix.score(tf, df, doc)
+			}
+		}
+		scores = next
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	docs := make([]*document, 0, len(scores))
+	for doc := range scores {
+		docs = append(docs, doc)
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		a, b := docs[i], docs[j]
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+		if (a.vertex == nil) != (b.vertex == nil) {
+			return a.vertex != nil
+		}
+		return a.id() < b.id()
+	})
+	for _, doc := range docs {
+		hits = append(hits, Hit{Vertex: doc.vertex, Edge: doc.edge, Score: scores[doc]})
+	}
+	return hits
+}
@@ -0,0 +1,158 @@
+package graph
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func movieGraph() *Graph {
+	g := NewDirected()
+	g.Vertex("0").Label("Movie").Set("title", "The Matrix").Set("year", "1999-03-31")
+	g.Vertex("1").Label("Movie").Set("title", "The Matrix Reloaded").Set("year", "2003-05-07")
+	g.Vertex("2").Label("Movie").Set("title", "The Matrix Revolutions").Set("year", "2003-10-27")
+	g.Vertex("3").Label("Actor").Set("name", "Keanu Reeves")
+	g.Vertex("4").Label("Actor").Set("name", "Laurence Fishburne")
+	for _, m := range []string{"0", "1", "2"} {
+		g.Edge("3", m).Label("ACTS_IN").Set("role", "Neo")
+		g.Edge("4", m).Label("ACTS_IN").Set("role", "Morpheus")
+	}
+	return g
+}
+
+func hitIds(hits []Hit) []string {
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		if h.Vertex != nil {
+			ids[i] = h.Vertex.Id()
+		} else {
+			ids[i] = h.Edge.Id()
+		}
+	}
+	return ids
+}
+
+func TestTextIndex(t *testing.T) {
+	g := movieGraph()
+	ix := g.TextIndex()
+
+	if hits := ix.Search("matrix reloaded"); len(hits) != 1 || hits[0].Vertex.GetLabel() != "Movie" || hits[0].Vertex.Id() != "1" {
+		t.Errorf("Error searching matrix reloaded: %v", hitIds(hits))
+	}
+	if hits := ix.Search("MATRIX"); len(hits) != 3 || hits[0].Vertex.Id() != "0" || hits[0].Score <= hits[1].Score {
+		t.Errorf("Error ranking matrix (shortest title first): %v", hitIds(hits))
+	}
+	if hits := ix.Search("revol*"); len(hits) != 1 || hits[0].Vertex.Id() != "2" {
+		t.Errorf("Error prefix search: %v", hitIds(hits))
+	}
+	if hits := ix.Search(`"the matrix reloaded"`); len(hits) != 1 || hits[0].Vertex.Id() != "1" {
+		t.Errorf("Error phrase search: %v", hitIds(hits))
+	}
+	if hits := ix.Search(`"reloaded matrix"`); len(hits) != 0 {
+		t.Errorf("Error phrase out of order: %v", hitIds(hits))
+	}
+	if hits := ix.Search("neo"); len(hits) != 3 || hits[0].Edge == nil || hits[0].Edge.GetLabel() != "ACTS_IN" {
+		t.Errorf("Error searching edges: %v", hitIds(hits))
+	}
+	if hits := ix.Search("2003"); len(hits) != 2 {
+		t.Errorf("Error searching digits: %v", hitIds(hits))
+	}
+	if hits := ix.Search(`"" *`); len(hits) != 0 {
+		t.Errorf("Error empty query: %v", hitIds(hits))
+	}
+
+	// incremental updates
+	g.Vertex("1").Set("title", "Matrix Resurrections")
+	if hits := ix.Search("reloaded"); len(hits) != 0 {
+		t.Errorf("Error replaced value still found: %v", hitIds(hits))
+	}
+	if hits := ix.Search("resurrections"); len(hits) != 1 || hits[0].Vertex.Id() != "1" {
+		t.Errorf("Error new value not found: %v", hitIds(hits))
+	}
+	g.Vertex("1").Unset("title")
+	if hits := ix.Search("matrix"); len(hits) != 2 {
+		t.Errorf("Error unset value still found: %v", hitIds(hits))
+	}
+	g.Vertex("3").Remove()
+	if hits := ix.Search("neo keanu"); len(hits) != 0 {
+		t.Errorf("Error removed vertex or edges still found: %v", hitIds(hits))
+	}
+	if ix.docs != g.VertexCount()+g.EdgeCount() {
+		t.Errorf("Error document count: %v", ix.docs)
+	}
+	g.Edge("5", "0").Set("role", "Agent Smith").Set("quotes", []string{"Mr Anderson", "inevitable"})
+	g.Vertex("5").Set("name", "Hugo Weaving")
+	if hits := ix.Search("smith"); len(hits) != 1 || hits[0].Edge == nil {
+		t.Errorf("Error new edge not found: %v", hitIds(hits))
+	}
+	if hits := ix.Search("anderson inev*"); len(hits) != 1 {
+		t.Errorf("Error array values not found: %v", hitIds(hits))
+	}
+	if hits := ix.Search(`"anderson inevitable"`); len(hits) != 0 {
+		t.Errorf("Error phrase across array values: %v", hitIds(hits))
+	}
+	if hits := ix.Search("weaving"); len(hits) != 1 || hits[0].Vertex.Id() != "5" {
+		t.Errorf("Error new vertex not found: %v", hitIds(hits))
+	}
+
+	ix.Ranking = TFIDF
+	if hits := ix.Search("morpheus"); len(hits) != 3 || hits[0].Score <= 0 {
+		t.Errorf("Error TF-IDF ranking: %v", hits)
+	}
+	if g.TextIndex() != ix {
+		t.Errorf("Error index recreated")
+	}
+}
+
+func TestTextIndexImporter(t *testing.T) {
+	g := NewDirected()
+	g.TextIndex()
+	im := NewImporter(g)
+	im.Bulk = true
+	if err := im.Nodes(strings.NewReader(movies)); err != nil {
+		t.Fatalf("Error importing: %v", err)
+	}
+	if hits := g.TextIndex().Search("matrix reloaded"); len(hits) != 1 {
+		t.Errorf("Error imported vertex not indexed: %v", hitIds(hits))
+	}
+}
+
+func TestTextIndexConcurrentSearch(t *testing.T) {
+	g := movieGraph()
+	ix := g.TextIndex()
+	g.Vertex("5").Set("name", "Carrie-Anne Moss")
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if hits := ix.Search("matr* carr*"); len(hits) != 0 {
+				t.Errorf("Error concurrent prefix search: %v", hitIds(hits))
+			}
+		}()
+	}
+	wg.Wait()
+	if len(ix.terms) != len(ix.postings) || !sort.StringsAreSorted(ix.terms) {
+		t.Errorf("Error sorted vocabulary: %v", ix.terms)
+	}
+}
+
+func TestTextIndexUnmarshal(t *testing.T) {
+	g := movieGraph()
+	ix := g.TextIndex()
+	raw, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Error marshaling: %v", err)
+	}
+	if err := json.Unmarshal(raw, g); err != nil {
+		t.Fatalf("Error unmarshaling: %v", err)
+	}
+	if g.TextIndex() != ix || ix.docs != g.VertexCount()+g.EdgeCount() {
+		t.Errorf("Error index after unmarshal: %d", ix.docs)
+	}
+	if hits := ix.Search("reloaded"); len(hits) != 1 || hits[0].Vertex != g.Vertex("1") {
+		t.Errorf("Error search after unmarshal: %v", hitIds(hits))
+	}
+}
@@ -0,0 +1,240 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+)
+
+var ErrNegativeCycle = errors.New("graph has a negative cycle")
+
+type Distances struct {
+	vertices []*Vertex
+	index    map[string]int
+	dist     [][]float64
+	prev     [][]*Edge
+}
+
+func newDistances(g *Graph) *Distances {
+	vertices := g.Vertices()
+	n := len(vertices)
+	d := &Distances{
+		vertices: vertices,
+		index:    make(map[string]int, n),
+		dist:     make([][]float64, n),
+		prev:     make([][]*Edge, n),
+	}
+	for i, v := range vertices {
+		d.index[v.id] = i
+		d.dist[i] = make([]float64, n)
+		d.prev[i] = make([]*Edge, n)
+		for j := range d.dist[i] {
+			d.dist[i][j] = math.Inf(1)
+		}
+		d.dist[i][i] = 0
+	}
+	return d
+}
+
+func (d *Distances) Distance(from, to string) (float64, bool) {
+	i, ok1 := d.index[from]
+	j, ok2 := d.index[to]
+	if !ok1 || !ok2 || math.IsInf(d.dist[i][j], 1) {
+		return math.Inf(1), false
+	}
+	return d.dist[i][j], true
+}
+
+func (d *Distances) Path(from, to string) *Path {
+	dist, ok := d.Distance(from, to)
+	if !ok {
+		return nil
+	}
+	i, j := d.index[from], d.index[to]
+	p := &Path{Weight: dist}
+	cur := d.vertices[j]
+	for cur != d.vertices[i] {
+		e := d.prev[i][d.index[cur.id]]
+		p.Vertices = append(p.Vertices, cur)
+		p.Edges = append(p.Edges, e)
+		cur = e.tail(cur)
+	}
+	p.Vertices = append(p.Vertices, cur)
+	reverseVertices(p.Vertices)
+	reverseEdges(p.Edges)
+	return p
+}
+
+func (d *Distances) Matrix() map[string]map[string]float64 {
+	m := make(map[string]map[string]float64, len(d.vertices))
+	for i, v := range d.vertices {
+		row := make(map[string]float64)
+		for j, u := range d.vertices {
+			if !math.IsInf(d.dist[i][j], 1) {
+				row[u.id] = d.dist[i][j]
+			}
+		}
+		m[v.id] = row
+	}
+	return m
+}
+
+func reverseVertices(s []*Vertex) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func reverseEdges(s []*Edge) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func FloydWarshall(g *Graph, weight string) (*Distances, error) {
+	weights, err := edgeWeights(g, weight)
+	if err != nil {
+		return nil, err
+	}
+	d := newDistances(g)
+	n := len(d.vertices)
+
+	for _, e := range g.AllEdges() {
+		w := weights[e]
+		for k, v := range e.link {
+			i, j := d.index[k], d.index[v.id]
+			if i == j {
+				if w < 0 {
+					return nil, ErrNegativeCycle
+				}
+				continue
+			}
+			if w < d.dist[i][j] {
+				d.dist[i][j] = w
+				d.prev[i][j] = e
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if math.IsInf(d.dist[i][k], 1) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if dist := d.dist[i][k] + d.dist[k][j]; dist < d.dist[i][j] {
+					d.dist[i][j] = dist
+					d.prev[i][j] = d.prev[k][j]
+				}
+			}
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if d.dist[i][i] < 0 {
+			return nil, ErrNegativeCycle
+		}
+	}
+	return d, nil
+}
+
+func Johnson(g *Graph, weight string) (*Distances, error) {
+	weights, err := edgeWeights(g, weight)
+	if err != nil {
+		return nil, err
+	}
+	h, err := bellmanFord(g, weights)
+	if err != nil {
+		return nil, err
+	}
+	d := newDistances(g)
+	reweight := func(e *Edge, from, to *Vertex) float64 {
+		return weights[e] + h[from] - h[to]
+	}
+	for i, s := range d.vertices {
+		dist, prev := dijkstra(s, reweight, nil)
+		for v, dv := range dist {
+			j := d.index[v.id]
+			d.dist[i][j] = dv - h[s] + h[v]
+			d.prev[i][j] = prev[v]
+		}
+	}
+	return d, nil
+}
+
+// Potentials from a virtual source linked to every vertex with weight 0.
+func bellmanFord(g *Graph, weights map[*Edge]float64) (map[*Vertex]float64, error) {
+	h := make(map[*Vertex]float64, len(g.vertices))
+	for _, v := range g.vertices {
+		h[v] = 0
+	}
+	edges := g.AllEdges()
+	relax := func() bool {
+		changed := false
+		for _, e := range edges {
+			for k, to := range e.link {
+				from := g.vertices[k]
+				if d := h[from] + weights[e]; d < h[to] {
+					h[to] = d
+					changed = true
+				}
+			}
+		}
+		return changed
+	}
+	for i := 0; i < len(g.vertices); i++ {
+		if !relax() {
+			return h, nil
+		}
+	}
+	if relax() {
+		return nil, ErrNegativeCycle
+	}
+	return h, nil
+}
+
+type dijkstraItem struct {
+	v    *Vertex
+	dist float64
+}
+
+type dijkstraQueue []dijkstraItem
+
+func (q dijkstraQueue) Len() int            { return len(q) }
+func (q dijkstraQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q dijkstraQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *dijkstraQueue) Push(x interface{}) { *q = append(*q, x.(dijkstraItem)) }
+func (q *dijkstraQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+func dijkstra(src *Vertex, weight func(e *Edge, from, to *Vertex) float64, accept func(e *Edge, to *Vertex) bool) (map[*Vertex]float64, map[*Vertex]*Edge) {
+	dist := map[*Vertex]float64{src: 0}
+	prev := make(map[*Vertex]*Edge)
+	done := make(map[*Vertex]bool)
+	q := &dijkstraQueue{{src, 0}}
+	for q.Len() > 0 {
+		item := heap.Pop(q).(dijkstraItem)
+		v := item.v
+		if done[v] {
+			continue
+		}
+		done[v] = true
+		for _, e := range v.outEdges() {
+			u := e.adjacent(v)
+			if u == v || done[u] || (accept != nil && !accept(e, u)) {
+				continue
+			}
+			d := item.dist + weight(e, v, u)
+			if du, ok := dist[u]; !ok || d < du {
+				dist[u] = d
+				prev[u] = e
+				heap.Push(q, dijkstraItem{u, d})
+			}
+		}
+	}
+	return dist, prev
+}
@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"testing"
+)
+
+func testDistances(t *testing.T, name string, d *Distances) {
+	tests := []struct {
+		from, to string
+		dist     float64
+		path     []string
+	}{
+		{"a", "a", 0, []string{"a"}},
+		{"a", "b", 3, []string{"a", "c", "b"}},
+		{"a", "d", 2, []string{"a", "c", "b", "d"}},
+		{"b", "c", 6, []string{"b", "d", "c"}},
+		{"d", "b", 8, []string{"d", "c", "b"}},
+	}
+	for _, test := range tests {
+		if dist, ok := d.Distance(test.from, test.to); !ok || dist != test.dist {
+			t.Errorf("(%s) Error distance %s-%s (%g): %g", name, test.from, test.to, test.dist, dist)
+		}
+		p := d.Path(test.from, test.to)
+		if ids := vertexIds(p.Vertices); len(ids) != len(test.path) || len(p.Edges) != len(ids)-1 {
+			t.Errorf("(%s) Error path %s-%s %v: %v", name, test.from, test.to, test.path, ids)
+		} else {
+			for i := range ids {
+				if ids[i] != test.path[i] {
+					t.Errorf("(%s) Error path %s-%s %v: %v", name, test.from, test.to, test.path, ids)
+					break
+				}
+			}
+		}
+	}
+	if _, ok := d.Distance("e", "a"); ok {
+		t.Errorf("(%s) Error e should not reach a", name)
+	}
+	if p := d.Path("a", "e"); p != nil {
+		t.Errorf("(%s) Error a should not reach e: %v", name, p)
+	}
+	if m := d.Matrix(); len(m["a"]) != 4 || len(m["e"]) != 1 {
+		t.Errorf("(%s) Error matrix rows (4, 1): %d, %d", name, len(m["a"]), len(m["e"]))
+	}
+}
+
+func TestAllPairsShortestPaths(t *testing.T) {
+	g := NewDirected()
+	g.Edge("a", "b").Set("w", 4)
+	g.Edge("a", "c").Set("w", 2)
+	g.Edge("c", "b").Set("w", 1)
+	g.Edge("b", "d").Set("w", -1)
+	g.Edge("b", "d").Set("w", 5)
+	g.Edge("d", "c").Set("w", "7")
+	g.Vertex("e")
+
+	d, err := FloydWarshall(g, "w")
+	if err != nil {
+		t.Fatalf("Error floyd-warshall: %v", err)
+	}
+	testDistances(t, "floyd-warshall", d)
+
+	d, err = Johnson(g, "w")
+	if err != nil {
+		t.Fatalf("Error johnson: %v", err)
+	}
+	testDistances(t, "johnson", d)
+
+	g.Edge("d", "b").Set("w", -2)
+	if _, err := FloydWarshall(g, "w"); err != ErrNegativeCycle {
+		t.Errorf("Error floyd-warshall negative cycle: %v", err)
+	}
+	if _, err := Johnson(g, "w"); err != ErrNegativeCycle {
+		t.Errorf("Error johnson negative cycle: %v", err)
+	}
+
+	g.Edge("a", "e").Set("w", "far")
+	if _, err := Johnson(g, "w"); err == nil {
+		t.Errorf("Error invalid weight should fail")
+	}
+}
+
+func TestAllPairsUnweighted(t *testing.T) {
+	g := NewUndirected()
+	g.Edge("1", "2")
+	g.Edge("2", "3")
+	g.Edge("3", "4")
+	g.Edge("4", "1")
+
+	d, err := FloydWarshall(g, "")
+	if err != nil {
+		t.Fatalf("Error floyd-warshall: %v", err)
+	}
+	if dist, _ := d.Distance("1", "3"); dist != 2 {
+		t.Errorf("Error distance 1-3 (2): %g", dist)
+	}
+	if dist, _ := d.Distance("4", "1"); dist != 1 {
+		t.Errorf("Error distance 4-1 (1): %g", dist)
+	}
+}
@@ -0,0 +1,178 @@
+package graph
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// Cardinality sketch with 2^precision registers, precision from 4 to 16;
+// the relative error is about 1.04/sqrt(2^precision).
+type HyperLogLog struct {
+	precision uint
+	registers []uint8
+}
+
+func NewHyperLogLog(precision uint) *HyperLogLog {
+	precision = max(4, min(precision, 16))
+	return &HyperLogLog{precision: precision, registers: make([]uint8, 1<<precision)}
+}
+
+// FNV-1a with the splitmix64 finalizer, FNV alone leaves the high bits poorly mixed.
+func hash64(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+func (h *HyperLogLog) Add(s string) {
+	h.addHash(hash64(s))
+}
+
+func (h *HyperLogLog) addHash(x uint64) {
+	i := x >> (64 - h.precision)
+	rank := uint8(bits.LeadingZeros64(x<<h.precision|1<<(h.precision-1)) + 1)
+	if rank > h.registers[i] {
+		h.registers[i] = rank
+	}
+}
+
+// Union with a sketch of the same precision; reports whether h changed.
+func (h *HyperLogLog) Merge(o *HyperLogLog) bool {
+	changed := false
+	for i, r := range o.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (h *HyperLogLog) Clone() *HyperLogLog {
+	return &HyperLogLog{precision: h.precision, registers: append([]uint8{}, h.registers...)}
+}
+
+func (h *HyperLogLog) Count() float64 {
+	m := float64(len(h.registers))
+	var alpha float64
+	switch len(h.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+	sum, zeros := 0.0, 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha * m * m / sum
+	// linear counting for small cardinalities
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return estimate
+}
+
+// Hops bounds the iterations, run until no neighborhood grows when zero.
+// Precision is the HyperLogLog register bits, 8 when zero.
+type ANFOptions struct {
+	Hops      int
+	Precision uint
+}
+
+// Pairs[h] estimates the pairs (u, v) with v reachable from u in at most h
+// hops along out edges, u itself included; Reach has the same per vertex.
+type Neighborhood struct {
+	Pairs []float64
+	Reach map[string][]float64
+}
+
+// HyperANF approximate neighborhood function: every vertex keeps a sketch of
+// the vertices within h hops and merges the sketches of its out neighbors at
+// each hop, in memory linear in the vertex count.
+func ApproximateNeighborhood(g *Graph, opt ANFOptions) *Neighborhood {
+	if opt.Precision == 0 {
+		opt.Precision = 8
+	}
+	vertices := g.Vertices()
+	index := make(map[*Vertex]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	out := make([][]int, len(vertices))
+	cur := make([]*HyperLogLog, len(vertices))
+	n := &Neighborhood{Reach: make(map[string][]float64, len(vertices))}
+	pairs := 0.0
+	for i, v := range vertices {
+		for _, e := range v.outEdges() {
+			if u := e.adjacent(v); u != v {
+				out[i] = append(out[i], index[u])
+			}
+		}
+		cur[i] = NewHyperLogLog(opt.Precision)
+		cur[i].Add(v.id)
+		count := cur[i].Count()
+		n.Reach[v.id] = []float64{count}
+		pairs += count
+	}
+	n.Pairs = []float64{pairs}
+
+	for h := 1; opt.Hops <= 0 || h <= opt.Hops; h++ {
+		next := make([]*HyperLogLog, len(vertices))
+		changed := false
+		pairs = 0
+		for i, v := range vertices {
+			next[i] = cur[i].Clone()
+			for _, j := range out[i] {
+				if next[i].Merge(cur[j]) {
+					changed = true
+				}
+			}
+			count := next[i].Count()
+			n.Reach[v.id] = append(n.Reach[v.id], count)
+			pairs += count
+		}
+		if !changed && opt.Hops <= 0 {
+			// drop the hop that added nothing
+			for _, v := range vertices {
+				n.Reach[v.id] = n.Reach[v.id][:h]
+			}
+			break
+		}
+		n.Pairs = append(n.Pairs, pairs)
+		cur = next
+	}
+	return n
+}
+
+// Hops, interpolated, within which a fraction q (0.9 is usual) of the pairs
+// reachable at the last hop lie.
+func (n *Neighborhood) EffectiveDiameter(q float64) float64 {
+	if len(n.Pairs) == 0 {
+		return 0
+	}
+	target := q * n.Pairs[len(n.Pairs)-1]
+	for h, p := range n.Pairs {
+		if p >= target {
+			if h == 0 {
+				return 0
+			}
+			prev := n.Pairs[h-1]
+			return float64(h-1) + (target-prev)/(p-prev)
+		}
+	}
+	return float64(len(n.Pairs) - 1)
+}
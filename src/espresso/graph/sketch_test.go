@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	a, b := NewHyperLogLog(12), NewHyperLogLog(12)
+	for i := 0; i < 20000; i++ {
+		a.Add(fmt.Sprint(i))
+		a.Add(fmt.Sprint(i))
+		b.Add(fmt.Sprint(i + 10000))
+	}
+	if c := a.Count(); math.Abs(c-20000)/20000 > 0.05 {
+		t.Errorf("Error estimate of 20000: %v", c)
+	}
+	u := a.Clone()
+	if !u.Merge(b) || u.Merge(b) {
+		t.Errorf("Error merge changes")
+	}
+	if c := u.Count(); math.Abs(c-30000)/30000 > 0.05 {
+		t.Errorf("Error estimate of union of 30000: %v", c)
+	}
+	if c := a.Count(); math.Abs(c-20000)/20000 > 0.05 {
+		t.Errorf("Error clone shares registers: %v", c)
+	}
+	small := NewHyperLogLog(0)
+	for _, s := range []string{"a", "b", "c", "a"} {
+		small.Add(s)
+	}
+	if c := small.Count(); math.Abs(c-3) > 0.5 || len(small.registers) != 16 {
+		t.Errorf("Error small estimate: %v", c)
+	}
+}
+
+func TestApproximateNeighborhood(t *testing.T) {
+	// directed path 0 -> 1 -> ... -> 9
+	g := NewDirected()
+	for i := 0; i < 9; i++ {
+		g.Edge(fmt.Sprint(i), fmt.Sprint(i+1))
+	}
+	n := ApproximateNeighborhood(g, ANFOptions{Precision: 10})
+	if len(n.Pairs) != 10 {
+		t.Fatalf("Error hops until convergence: %v", n.Pairs)
+	}
+	for h, p := range n.Pairs {
+		exact := 0
+		for i := 0; i < 10; i++ {
+			exact += min(h, 9-i) + 1
+		}
+		if math.Abs(p-float64(exact)) > 0.05*float64(exact) {
+			t.Errorf("Error pairs within %d hops: %v != %v", h, p, exact)
+		}
+	}
+	if r := n.Reach["0"]; math.Abs(r[9]-10) > 0.5 || math.Abs(r[3]-4) > 0.5 {
+		t.Errorf("Error reach of 0: %v", r)
+	}
+	if r := n.Reach["9"]; len(r) != 10 || math.Abs(r[9]-1) > 0.5 {
+		t.Errorf("Error reach of 9: %v", r)
+	}
+	if d := n.EffectiveDiameter(1); math.Abs(d-9) > 0.5 {
+		t.Errorf("Error effective diameter: %v", d)
+	}
+	if d := n.EffectiveDiameter(0.9); d < 5 || d > 8 {
+		t.Errorf("Error 90%% effective diameter: %v", d)
+	}
+
+	if n := ApproximateNeighborhood(g, ANFOptions{Hops: 2}); len(n.Pairs) != 3 || len(n.Reach["0"]) != 3 {
+		t.Errorf("Error hop limit: %v", n.Pairs)
+	}
+	if n := ApproximateNeighborhood(New(), ANFOptions{}); len(n.Pairs) != 1 || n.Pairs[0] != 0 || n.EffectiveDiameter(0.9) != 0 {
+		t.Errorf("Error empty neighborhood: %v", n.Pairs)
+	}
+}
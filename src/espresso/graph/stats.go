@@ -0,0 +1,249 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type DegreeStats struct {
+	Min, Max  int
+	Mean      float64
+	Histogram map[int]int
+}
+
+// Label counts use "" for unlabeled elements; property usage is counted per
+// label and key. Diameter is a double sweep lower bound ignoring direction.
+// Density counts the ordered vertex pairs each edge joins: one for a directed
+// edge, two for an undirected one and k(k-1) for a hyperedge of k vertices.
+type GraphStats struct {
+	Vertices, Edges     int
+	HyperEdges          int
+	VertexLabels        map[string]int
+	EdgeLabels          map[string]int
+	InDegree, OutDegree DegreeStats
+	Density             float64
+	SelfLoops           int
+	MultiEdges          int
+	Components          int
+	Diameter            int
+	VertexProperties    map[string]map[string]int
+	EdgeProperties      map[string]map[string]int
+}
+
+func degreeStats(degrees []int) DegreeStats {
+	s := DegreeStats{Histogram: make(map[int]int)}
+	for i, d := range degrees {
+		if i == 0 || d < s.Min {
+			s.Min = d
+		}
+		if d > s.Max {
+			s.Max = d
+		}
+		s.Mean += float64(d)
+		s.Histogram[d]++
+	}
+	if len(degrees) > 0 {
+		s.Mean /= float64(len(degrees))
+	}
+	return s
+}
+
+func countProperties(counts map[string]map[string]int, label string, d *data) {
+	if counts[label] == nil {
+		counts[label] = make(map[string]int)
+	}
+	for k := range d.values {
+		counts[label][k]++
+	}
+}
+
+func Stats(g *Graph) *GraphStats {
+	s := &GraphStats{
+		Vertices:         g.VertexCount(),
+		Edges:            g.EdgeCount(),
+		HyperEdges:       g.HyperEdgeCount(),
+		VertexLabels:     make(map[string]int),
+		EdgeLabels:       make(map[string]int),
+		VertexProperties: make(map[string]map[string]int),
+		EdgeProperties:   make(map[string]map[string]int),
+	}
+	vertices := g.Vertices()
+	in := make([]int, len(vertices))
+	out := make([]int, len(vertices))
+	for i, v := range vertices {
+		s.VertexLabels[v.label]++
+		countProperties(s.VertexProperties, v.label, &v.data)
+		in[i], out[i] = len(v.inEdges()), len(v.outEdges())
+	}
+	s.InDegree, s.OutDegree = degreeStats(in), degreeStats(out)
+
+	pairs := make(map[[2]*Vertex]int)
+	joined := 0
+	for _, e := range append(g.AllEdges(), g.HyperEdges()...) {
+		s.EdgeLabels[e.label]++
+		countProperties(s.EdgeProperties, e.label, &e.data)
+		switch k := len(e.members); {
+		case e.members != nil:
+			joined += k * (k - 1)
+			continue
+		case e.directed:
+			joined++
+		default:
+			joined += 2
+		}
+		from, to := e.Ends()
+		if from == to {
+			s.SelfLoops++
+		}
+		if !e.directed && to.id < from.id {
+			from, to = to, from
+		}
+		pair := [2]*Vertex{from, to}
+		if pairs[pair]++; pairs[pair] > 1 {
+			s.MultiEdges++
+		}
+	}
+	if n := float64(len(vertices)); n > 1 {
+		s.Density = float64(joined) / (n * (n - 1))
+	}
+
+	for _, c := range components(g) {
+		s.Components++
+		// double sweep: farthest vertex from any start, then farthest from that one
+		far, _ := eccentricity(c[0])
+		if _, d := eccentricity(far); d > s.Diameter {
+			s.Diameter = d
+		}
+	}
+	return s
+}
+
+// Weakly connected components, hyperedges included, each sorted by id.
+func components(g *Graph) [][]*Vertex {
+	parent := make(map[*Vertex]*Vertex, len(g.vertices))
+	var find func(v *Vertex) *Vertex
+	find = func(v *Vertex) *Vertex {
+		if p := parent[v]; p != v {
+			parent[v] = find(p)
+		}
+		return parent[v]
+	}
+	union := func(a, b *Vertex) {
+		if ra, rb := find(a), find(b); ra != rb {
+			parent[ra] = rb
+		}
+	}
+	vertices := g.Vertices()
+	for _, v := range vertices {
+		parent[v] = v
+	}
+	for _, e := range append(g.AllEdges(), g.HyperEdges()...) {
+		members := e.Members()
+		for _, u := range members[1:] {
+			union(members[0], u)
+		}
+	}
+	index := make(map[*Vertex]int)
+	var out [][]*Vertex
+	for _, v := range vertices {
+		r := find(v)
+		i, ok := index[r]
+		if !ok {
+			i = len(out)
+			index[r] = i
+			out = append(out, nil)
+		}
+		out[i] = append(out[i], v)
+	}
+	return out
+}
+
+// Farthest vertex from v by breadth-first search ignoring direction.
+func eccentricity(v *Vertex) (*Vertex, int) {
+	depth := map[*Vertex]int{v: 0}
+	queue := []*Vertex{v}
+	far := v
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		if depth[u] > depth[far] {
+			far = u
+		}
+		for _, w := range u.neighbors() {
+			if _, seen := depth[w]; !seen {
+				depth[w] = depth[u] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return far, depth[far]
+}
+
+func labelName(label string) string {
+	if label == "" {
+		return "(none)"
+	}
+	return label
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (d DegreeStats) String() string {
+	out := fmt.Sprintf("min %d max %d mean %.2f\n", d.Min, d.Max, d.Mean)
+	degrees := make([]int, 0, len(d.Histogram))
+	for k := range d.Histogram {
+		degrees = append(degrees, k)
+	}
+	sort.Ints(degrees)
+	for _, k := range degrees {
+		out += fmt.Sprintf("  %d: %d\n", k, d.Histogram[k])
+	}
+	return out
+}
+
+func (s *GraphStats) String() string {
+	var b strings.Builder
+	counts := func(title string, total int, labels map[string]int) {
+		fmt.Fprintf(&b, "%s: %d\n", title, total)
+		for _, l := range sortedKeys(labels) {
+			fmt.Fprintf(&b, "  %s: %d\n", labelName(l), labels[l])
+		}
+	}
+	properties := func(title string, usage map[string]map[string]int) {
+		fmt.Fprintf(&b, "%s:\n", title)
+		labels := make([]string, 0, len(usage))
+		for l, keys := range usage {
+			if len(keys) > 0 {
+				labels = append(labels, l)
+			}
+		}
+		sort.Strings(labels)
+		for _, l := range labels {
+			keys := make([]string, 0, len(usage[l]))
+			for _, k := range sortedKeys(usage[l]) {
+				keys = append(keys, fmt.Sprintf("%s %d", k, usage[l][k]))
+			}
+			fmt.Fprintf(&b, "  %s: %s\n", labelName(l), strings.Join(keys, ", "))
+		}
+	}
+	counts("vertices", s.Vertices, s.VertexLabels)
+	counts("edges", s.Edges, s.EdgeLabels)
+	fmt.Fprintf(&b, "density: %.4f\n", s.Density)
+	fmt.Fprintf(&b, "self-loops: %d\n", s.SelfLoops)
+	fmt.Fprintf(&b, "multi-edges: %d\n", s.MultiEdges)
+	fmt.Fprintf(&b, "components: %d\n", s.Components)
+	fmt.Fprintf(&b, "diameter (approx): %d\n", s.Diameter)
+	fmt.Fprintf(&b, "out-degree: %s", s.OutDegree)
+	fmt.Fprintf(&b, "in-degree: %s", s.InDegree)
+	properties("vertex properties", s.VertexProperties)
+	properties("edge properties", s.EdgeProperties)
+	return b.String()
+}
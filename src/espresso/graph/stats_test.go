@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	g := NewDirected()
+	g.Vertex("a").Label("Person").Set("name", "A")
+	g.Vertex("b").Label("Person").Set("name", "B").Set("age", 3)
+	g.Vertex("c").Label("City")
+	g.Edge("a", "b").Label("KNOWS").Set("since", 2000)
+	g.Edge("a", "b").Label("KNOWS")
+	g.Edge("b", "c").Label("LIVES_IN")
+	g.Edge("c", "c")
+	g.Edge("x", "y")
+
+	s := Stats(g)
+	if s.Vertices != 5 || s.Edges != 5 {
+		t.Errorf("Error counts: %v %v", s.Vertices, s.Edges)
+	}
+	if s.VertexLabels["Person"] != 2 || s.VertexLabels["City"] != 1 || s.VertexLabels[""] != 2 {
+		t.Errorf("Error vertex labels: %v", s.VertexLabels)
+	}
+	if s.EdgeLabels["KNOWS"] != 2 || s.EdgeLabels["LIVES_IN"] != 1 || s.EdgeLabels[""] != 2 {
+		t.Errorf("Error edge labels: %v", s.EdgeLabels)
+	}
+	if s.SelfLoops != 1 || s.MultiEdges != 1 {
+		t.Errorf("Error self-loops and multi-edges: %v %v", s.SelfLoops, s.MultiEdges)
+	}
+	if s.Components != 2 || s.Diameter != 2 {
+		t.Errorf("Error components and diameter: %v %v", s.Components, s.Diameter)
+	}
+	if s.Density != 5.0/20 {
+		t.Errorf("Error density: %v", s.Density)
+	}
+	if s.OutDegree.Max != 2 || s.OutDegree.Min != 0 || s.OutDegree.Mean != 1 || s.OutDegree.Histogram[0] != 1 {
+		t.Errorf("Error out-degree: %v", s.OutDegree)
+	}
+	if s.InDegree.Max != 2 || s.InDegree.Histogram[2] != 2 {
+		t.Errorf("Error in-degree: %v", s.InDegree)
+	}
+	if s.VertexProperties["Person"]["name"] != 2 || s.VertexProperties["Person"]["age"] != 1 {
+		t.Errorf("Error vertex properties: %v", s.VertexProperties)
+	}
+	if s.EdgeProperties["KNOWS"]["since"] != 1 {
+		t.Errorf("Error edge properties: %v", s.EdgeProperties)
+	}
+	if r := s.String(); !strings.Contains(r, "  Person: age 1, name 2\n") || !strings.Contains(r, "components: 2\n") {
+		t.Errorf("Error report:\n%v", r)
+	}
+
+	u := NewUndirected()
+	u.Edge("1", "2")
+	u.Edge("2", "1")
+	u.Edge("2", "3")
+	u.Edge("3", "4")
+	if s := Stats(u); s.MultiEdges != 1 || s.Density != 8.0/12 || s.Diameter != 3 || s.Components != 1 {
+		t.Errorf("Error undirected stats: %v %v %v %v", s.MultiEdges, s.Density, s.Diameter, s.Components)
+	}
+
+	m := NewMixed()
+	m.Edge("1", "2")
+	m.UndirectedEdge("2", "3")
+	m.HyperEdge("1", "2", "3")
+	if s := Stats(m); s.Edges != 2 || s.HyperEdges != 1 || s.Density != 9.0/6 {
+		t.Errorf("Error mixed stats: %v %v %v", s.Edges, s.HyperEdges, s.Density)
+	}
+
+	if s := Stats(New()); s.Vertices != 0 || s.Components != 0 || s.Density != 0 {
+		t.Errorf("Error empty stats: %v", s)
+	}
+}
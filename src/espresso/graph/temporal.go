@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"time"
+)
+
+// Valid-time interval [From, To), To is zero while current.
+type Interval struct {
+	From, To time.Time
+}
+
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.From) && (i.To.IsZero() || t.Before(i.To))
+}
+
+type Version struct {
+	Interval
+	Value interface{}
+}
+
+// Clock and the vertices and edges removed since versioning started.
+type temporal struct {
+	now      func() time.Time
+	vertices []*Vertex
+	edges    []*Edge
+}
+
+// Records versions from now on: Set and Unset close the current value instead
+// of overwriting it and removed vertices and edges are kept for AsOf.
+// Labels are not versioned; now defaults to time.Now.
+func (g *Graph) Temporal(now func() time.Time) *Graph {
+	if now == nil {
+		now = time.Now
+	}
+	if g.temporal != nil {
+		g.temporal.now = now
+		return g
+	}
+	t := &temporal{now: now}
+	at := now()
+	g.temporal = t
+	g.data.track(t, at)
+	for _, v := range g.vertices {
+		v.valid.From = at
+		v.data.track(t, at)
+	}
+	for _, e := range g.AllEdges() {
+		e.valid.From = at
+		e.data.track(t, at)
+	}
+	return g
+}
+
+func (g *Graph) IsTemporal() bool {
+	return g.temporal != nil
+}
+
+func (d *data) track(t *temporal, at time.Time) {
+	d.temporal = t
+	d.versions = make(map[string][]Version, len(d.values))
+	for k, v := range d.values {
+		d.versions[k] = []Version{{Interval{From: at}, v}}
+	}
+}
+
+// Closes the current version of key and opens a new one when set.
+func (d *data) record(key string, value interface{}, set bool) {
+	at := d.temporal.now()
+	if d.versions == nil {
+		d.versions = make(map[string][]Version)
+	}
+	versions := d.versions[key]
+	if n := len(versions); n > 0 && versions[n-1].To.IsZero() {
+		versions[n-1].To = at
+	}
+	if set {
+		versions = append(versions, Version{Interval{From: at}, value})
+	}
+	d.versions[key] = versions
+}
+
+func (t *temporal) archive(valid *Interval, d *data) {
+	at := t.now()
+	valid.To = at
+	for _, versions := range d.versions {
+		if n := len(versions); n > 0 && versions[n-1].To.IsZero() {
+			versions[n-1].To = at
+		}
+	}
+}
+
+// Versions of a property, oldest first; empty unless the graph is temporal.
+func (d *data) History(key string) []Version {
+	return append([]Version{}, d.versions[key]...)
+}
+
+func (d *data) GetAt(key string, t time.Time) (interface{}, bool) {
+	if d.temporal == nil {
+		return d.Get(key)
+	}
+	for _, v := range d.versions[key] {
+		if v.Contains(t) {
+			return v.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (d *data) valuesAt(t time.Time) map[string]interface{} {
+	if d.temporal == nil {
+		return d.values
+	}
+	values := make(map[string]interface{})
+	for k := range d.versions {
+		if v, ok := d.GetAt(k, t); ok {
+			values[k] = v
+		}
+	}
+	return values
+}
+
+func (v *Vertex) Valid() Interval {
+	return v.valid
+}
+
+func (e *Edge) Valid() Interval {
+	return e.valid
+}
+
+// Snapshot of the graph at t as a new non-temporal graph; a graph without
+// versioning is copied as it is now.
+func (g *Graph) AsOf(t time.Time) *Graph {
+	c := &Graph{_type: g._type}
+	c.SetMap(g.data.valuesAt(t))
+	vertices := g.Vertices()
+	edges := append(g.AllEdges(), g.HyperEdges()...)
+	if g.temporal != nil {
+		vertices = append(vertices, g.temporal.vertices...)
+		edges = append(edges, g.temporal.edges...)
+	}
+	for _, v := range vertices {
+		if v.valid.Contains(t) {
+			c.Vertex(v.id).Label(v.label).SetMap(v.data.valuesAt(t))
+		}
+	}
+	for _, e := range edges {
+		if !e.valid.Contains(t) {
+			continue
+		}
+		c.copyKind(e).Label(e.label).SetMap(e.data.valuesAt(t))
+	}
+	return c
+}
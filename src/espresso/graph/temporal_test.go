@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTemporal(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(1999, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+	now := day(1)
+	g := NewDirected()
+	g.Vertex("keanu").Label("Actor").Set("name", "Keanu")
+	g.Temporal(func() time.Time { return now })
+
+	now = day(2)
+	g.Edge("keanu", "matrix").Label("ACTS_IN").Set("role", "Neo")
+	g.Vertex("matrix").Set("title", "The Matrix")
+
+	now = day(3)
+	g.Vertex("keanu").Set("name", "Keanu Reeves")
+	g.Edges("keanu", "matrix")[0].Set("role", "Thomas Anderson")
+
+	now = day(4)
+	g.Vertex("matrix").Unset("title")
+	g.Edge("keanu", "speed").Label("ACTS_IN")
+
+	now = day(5)
+	archived := g.Edges("keanu", "matrix")[0]
+	g.Vertex("keanu").Remove()
+
+	if g.VertexCount() != 2 || g.EdgeCount() != 0 {
+		t.Errorf("Error current graph (2, 0): %d, %d", g.VertexCount(), g.EdgeCount())
+	}
+
+	history := g.Vertex("matrix").History("title")
+	expected := []Version{{Interval{day(2), day(4)}, "The Matrix"}}
+	if !reflect.DeepEqual(history, expected) {
+		t.Errorf("Error title history: %v", history)
+	}
+
+	for _, c := range []struct {
+		at              time.Time
+		vertices, edges int
+		name, role      interface{}
+	}{
+		{day(1), 1, 0, "Keanu", nil},
+		{day(2), 2, 1, "Keanu", "Neo"},
+		{day(3), 2, 1, "Keanu Reeves", "Thomas Anderson"},
+		{day(4), 3, 2, "Keanu Reeves", "Thomas Anderson"},
+		{day(5), 2, 0, nil, nil},
+	} {
+		s := g.AsOf(c.at)
+		if s.VertexCount() != c.vertices || s.EdgeCount() != c.edges {
+			t.Errorf("Error snapshot %s size (%d, %d): %d, %d", c.at.Format("2006-01-02"), c.vertices, c.edges, s.VertexCount(), s.EdgeCount())
+		}
+		if s.IsTemporal() {
+			t.Errorf("Error snapshot should not be temporal")
+		}
+		if !s.HasVertex("keanu") {
+			continue
+		}
+		if name, _ := s.Vertex("keanu").Get("name"); name != c.name {
+			t.Errorf("Error name at %s (%v): %v", c.at.Format("2006-01-02"), c.name, name)
+		}
+		if s.Vertex("keanu").GetLabel() != "Actor" {
+			t.Errorf("Error label at %s", c.at.Format("2006-01-02"))
+		}
+		if e := s.Edges("keanu", "matrix"); len(e) == 1 {
+			if role, _ := e[0].Get("role"); role != c.role {
+				t.Errorf("Error role at %s (%v): %v", c.at.Format("2006-01-02"), c.role, role)
+			}
+		} else if c.role != nil {
+			t.Errorf("Error acts in edge at %s", c.at.Format("2006-01-02"))
+		}
+	}
+
+	if title, ok := g.AsOf(day(3)).Vertex("matrix").Get("title"); !ok || title != "The Matrix" {
+		t.Errorf("Error title as of day 3: %v", title)
+	}
+	if _, ok := g.Vertex("matrix").GetAt("title", day(4)); ok {
+		t.Errorf("Error title should be unset on day 4")
+	}
+	if v := g.Vertex("matrix").Valid(); !v.Contains(day(2)) || v.Contains(day(1)) {
+		t.Errorf("Error matrix valid interval: %v", v)
+	}
+
+	if from, to := archived.Ends(); from.Id() != "keanu" || to.Id() != "matrix" || len(archived.Members()) != 2 {
+		t.Errorf("Error archived edge ends: %v %v", from, to)
+	}
+	if v := archived.Valid(); v.To != day(5) {
+		t.Errorf("Error archived edge valid interval: %v", v)
+	}
+
+	now = day(6)
+	if e := g.Edge("reeves", "matrix"); e.Id() == archived.Id() {
+		t.Errorf("Error id of archived edge given again: %s", e.Id())
+	}
+	if e := g.AsOf(day(3)).EdgeById(archived.Id()); e == nil || e.Members()[0].Id() != "keanu" {
+		t.Errorf("Error archived edge id in snapshot: %v", e)
+	}
+
+	u := NewUndirected()
+	u.Edge("b", "a").Set("w", 1)
+	if s := u.AsOf(time.Now()); s.EdgeCount() != 1 || len(s.Edges("a", "b")) != 1 {
+		t.Errorf("Error snapshot of non temporal graph: %s", s)
+	}
+}
@@ -0,0 +1,66 @@
+package graph
+
+// Visits reachable vertices in breadth-first order until visit returns false.
+func BreadthFirst(g *Graph, from string, visit func(v *Vertex, depth int) bool) error {
+	return BreadthFirstWithin(g, from, -1, visit)
+}
+
+// Like BreadthFirst without going past max depth; a negative max has no limit.
+func BreadthFirstWithin(g *Graph, from string, max int, visit func(v *Vertex, depth int) bool) error {
+	start, ok := g.getVertex(from)
+	if !ok {
+		return ErrVertexNotFound
+	}
+	depth := map[*Vertex]int{start: 0}
+	queue := []*Vertex{start}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if !visit(v, depth[v]) {
+			return nil
+		}
+		if depth[v] == max {
+			continue
+		}
+		for _, e := range v.outEdges() {
+			u := e.adjacent(v)
+			if _, seen := depth[u]; !seen {
+				depth[u] = depth[v] + 1
+				queue = append(queue, u)
+			}
+		}
+	}
+	return nil
+}
+
+// Visits reachable vertices in depth-first preorder until visit returns false.
+func DepthFirst(g *Graph, from string, visit func(v *Vertex, depth int) bool) error {
+	return DepthFirstWithin(g, from, -1, visit)
+}
+
+// Like DepthFirst without going past max depth; a negative max has no limit.
+func DepthFirstWithin(g *Graph, from string, max int, visit func(v *Vertex, depth int) bool) error {
+	start, ok := g.getVertex(from)
+	if !ok {
+		return ErrVertexNotFound
+	}
+	seen := make(map[*Vertex]bool)
+	var walk func(v *Vertex, depth int) bool
+	walk = func(v *Vertex, depth int) bool {
+		seen[v] = true
+		if !visit(v, depth) {
+			return false
+		}
+		if depth == max {
+			return true
+		}
+		for _, e := range v.outEdges() {
+			if u := e.adjacent(v); !seen[u] && !walk(u, depth+1) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(start, 0)
+	return nil
+}
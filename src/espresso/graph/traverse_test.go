@@ -0,0 +1,63 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTraversal(t *testing.T) {
+	g := NewDirected()
+	g.Edge("a", "b")
+	g.Edge("a", "c")
+	g.Edge("b", "d")
+	g.Edge("c", "d")
+	g.Edge("d", "a")
+	g.Edge("e", "a")
+
+	collect := func(traverse func(*Graph, string, func(*Vertex, int) bool) error, limit int) (string, map[string]int) {
+		ids := make([]string, 0)
+		depths := make(map[string]int)
+		err := traverse(g, "a", func(v *Vertex, depth int) bool {
+			ids = append(ids, v.id)
+			depths[v.id] = depth
+			return len(ids) < limit
+		})
+		if err != nil {
+			t.Fatalf("Error traversal: %v", err)
+		}
+		return strings.Join(ids, ","), depths
+	}
+
+	if order, depths := collect(BreadthFirst, 10); order != "a,b,c,d" || depths["d"] != 2 {
+		t.Errorf("Error breadth first (a,b,c,d): %s %v", order, depths)
+	}
+	if order, depths := collect(DepthFirst, 10); order != "a,b,d,c" || depths["c"] != 1 {
+		t.Errorf("Error depth first (a,b,d,c): %s %v", order, depths)
+	}
+	if order, _ := collect(BreadthFirst, 2); order != "a,b" {
+		t.Errorf("Error breadth first should stop (a,b): %s", order)
+	}
+	if order, _ := collect(DepthFirst, 3); order != "a,b,d" {
+		t.Errorf("Error depth first should stop (a,b,d): %s", order)
+	}
+	within := func(traverse func(*Graph, string, int, func(*Vertex, int) bool) error, max int) string {
+		ids := make([]string, 0)
+		traverse(g, "a", max, func(v *Vertex, depth int) bool {
+			ids = append(ids, v.id)
+			return true
+		})
+		return strings.Join(ids, ",")
+	}
+	if order := within(BreadthFirstWithin, 1); order != "a,b,c" {
+		t.Errorf("Error breadth first within 1 (a,b,c): %s", order)
+	}
+	if order := within(DepthFirstWithin, 1); order != "a,b,c" {
+		t.Errorf("Error depth first within 1 (a,b,c): %s", order)
+	}
+	if order := within(DepthFirstWithin, 0); order != "a" {
+		t.Errorf("Error depth first within 0 (a): %s", order)
+	}
+	if err := BreadthFirst(g, "z", nil); err != ErrVertexNotFound {
+		t.Errorf("Error missing start vertex: %v", err)
+	}
+}
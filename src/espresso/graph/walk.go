@@ -0,0 +1,197 @@
+package graph
+
+import (
+	"bufio"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+// Length is the number of steps per walk and Walks the number of walks per
+// start vertex. Weight names an edge property for weighted transitions,
+// Restart is the chance of jumping back to the start at each step and P, Q
+// bias second order node2vec walks (1 when zero).
+type WalkOptions struct {
+	Length  int
+	Walks   int
+	Weight  string
+	Restart float64
+	P, Q    float64
+	Seed    int64
+}
+
+type walker struct {
+	opt      WalkOptions
+	weights  map[*Edge]float64
+	rand     *rand.Rand
+	adjacent map[*Vertex]map[*Vertex]bool
+}
+
+func newWalker(g *Graph, opt WalkOptions) (*walker, error) {
+	weights, err := nonNegativeWeights(g, opt.Weight)
+	if err != nil {
+		return nil, err
+	}
+	if opt.P <= 0 {
+		opt.P = 1
+	}
+	if opt.Q <= 0 {
+		opt.Q = 1
+	}
+	if opt.Walks <= 0 {
+		opt.Walks = 1
+	}
+	return &walker{
+		opt:      opt,
+		weights:  weights,
+		rand:     rand.New(rand.NewSource(opt.Seed)),
+		adjacent: make(map[*Vertex]map[*Vertex]bool),
+	}, nil
+}
+
+func (w *walker) second() bool {
+	return w.opt.P != 1 || w.opt.Q != 1
+}
+
+func (w *walker) isAdjacent(t, x *Vertex) bool {
+	set, ok := w.adjacent[t]
+	if !ok {
+		set = make(map[*Vertex]bool)
+		for _, e := range t.outEdges() {
+			set[e.adjacent(t)] = true
+		}
+		w.adjacent[t] = set
+	}
+	return set[x]
+}
+
+// Next vertex from v having arrived from prev, nil at a dead end.
+func (w *walker) step(prev, v *Vertex) *Vertex {
+	edges := v.outEdges()
+	if len(edges) == 0 {
+		return nil
+	}
+	probs := make([]float64, len(edges))
+	total := 0.0
+	for i, e := range edges {
+		p := w.weights[e]
+		if prev != nil && w.second() {
+			switch x := e.adjacent(v); {
+			case x == prev:
+				p /= w.opt.P
+			case !w.isAdjacent(prev, x):
+				p /= w.opt.Q
+			}
+		}
+		probs[i] = p
+		total += p
+	}
+	if total == 0 {
+		return nil
+	}
+	r := w.rand.Float64() * total
+	for i, p := range probs {
+		if r < p {
+			return edges[i].adjacent(v)
+		}
+		r -= p
+	}
+	return edges[len(edges)-1].adjacent(v)
+}
+
+func (w *walker) walk(start *Vertex) []string {
+	out := []string{start.id}
+	var prev *Vertex
+	v := start
+	for i := 0; i < w.opt.Length; i++ {
+		if w.opt.Restart > 0 && w.rand.Float64() < w.opt.Restart {
+			prev, v = nil, start
+			out = append(out, v.id)
+			continue
+		}
+		next := w.step(prev, v)
+		if next == nil {
+			break
+		}
+		prev, v = v, next
+		out = append(out, v.id)
+	}
+	return out
+}
+
+// Walk of up to Length steps from a vertex, shorter when it reaches a dead end.
+func RandomWalk(g *Graph, from string, opt WalkOptions) ([]string, error) {
+	start, ok := g.getVertex(from)
+	if !ok {
+		return nil, ErrVertexNotFound
+	}
+	w, err := newWalker(g, opt)
+	if err != nil {
+		return nil, err
+	}
+	return w.walk(start), nil
+}
+
+// Walks walks from every vertex, in rounds over the vertices sorted by id.
+func RandomWalks(g *Graph, opt WalkOptions) ([][]string, error) {
+	w, err := newWalker(g, opt)
+	if err != nil {
+		return nil, err
+	}
+	vertices := g.Vertices()
+	walks := make([][]string, 0, len(vertices)*w.opt.Walks)
+	for i := 0; i < w.opt.Walks; i++ {
+		for _, v := range vertices {
+			walks = append(walks, w.walk(v))
+		}
+	}
+	return walks, nil
+}
+
+// One walk per line, ids separated by spaces.
+func WriteWalks(out io.Writer, walks [][]string) error {
+	b := bufio.NewWriter(out)
+	for _, walk := range walks {
+		if _, err := b.WriteString(strings.Join(walk, " ") + "\n"); err != nil {
+			return err
+		}
+	}
+	return b.Flush()
+}
+
+// Monte Carlo estimate: Walks walks from source stop with probability Restart
+// (0.15 when zero) at each step and the share of walks ending at a vertex is
+// its rank. Dead ends jump back to source and Length caps the walks when positive.
+func PersonalizedPageRank(g *Graph, source string, opt WalkOptions) (map[string]float64, error) {
+	start, ok := g.getVertex(source)
+	if !ok {
+		return nil, ErrVertexNotFound
+	}
+	if opt.Restart <= 0 {
+		opt.Restart = 0.15
+	}
+	if opt.Walks <= 0 {
+		opt.Walks = 1000
+	}
+	w, err := newWalker(g, opt)
+	if err != nil {
+		return nil, err
+	}
+	rank := make(map[string]float64)
+	for i := 0; i < w.opt.Walks; i++ {
+		var prev *Vertex
+		v := start
+		for n := 0; opt.Length <= 0 || n < opt.Length; n++ {
+			if w.rand.Float64() < opt.Restart {
+				break
+			}
+			if next := w.step(prev, v); next != nil {
+				prev, v = v, next
+			} else {
+				prev, v = nil, start
+			}
+		}
+		rank[v.id] += 1 / float64(w.opt.Walks)
+	}
+	return rank, nil
+}
@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRandomWalk(t *testing.T) {
+	g := NewDirected()
+	g.Edge("a", "b").Set("w", 1)
+	g.Edge("a", "c").Set("w", 0)
+	g.Edge("b", "a").Set("w", 1)
+	g.Edge("c", "a").Set("w", 1)
+	g.Edge("b", "d").Set("w", 1)
+
+	opt := WalkOptions{Length: 20, Weight: "w", Seed: 7}
+	walk, err := RandomWalk(g, "a", opt)
+	if err != nil {
+		t.Fatalf("Error walking: %v", err)
+	}
+	if walk[0] != "a" || len(walk) > 21 {
+		t.Errorf("Error walk: %v", walk)
+	}
+	for i := 1; i < len(walk); i++ {
+		if walk[i] == "c" || len(g.Edges(walk[i-1], walk[i])) == 0 {
+			t.Errorf("Error walk step %s -> %s: %v", walk[i-1], walk[i], walk)
+		}
+	}
+	if walk[len(walk)-1] != "d" && len(walk) != 21 {
+		t.Errorf("Error walk should stop only at dead end: %v", walk)
+	}
+	again, _ := RandomWalk(g, "a", opt)
+	if !reflect.DeepEqual(walk, again) {
+		t.Errorf("Error seeded walks differ: %v %v", walk, again)
+	}
+
+	opt = WalkOptions{Length: 30, Restart: 0.5, Seed: 1}
+	walk, _ = RandomWalk(g, "c", opt)
+	restarts := 0
+	for i := 1; i < len(walk); i++ {
+		if len(g.Edges(walk[i-1], walk[i])) == 0 {
+			if walk[i] != "c" {
+				t.Errorf("Error restart should jump to start: %v", walk)
+			}
+			restarts++
+		}
+	}
+	if restarts == 0 {
+		t.Errorf("Error walk should restart: %v", walk)
+	}
+
+	if _, err := RandomWalk(g, "x", opt); err != ErrVertexNotFound {
+		t.Errorf("Error walking from missing vertex: %v", err)
+	}
+	g.Edge("d", "a").Set("w", -1)
+	if _, err := RandomWalk(g, "a", WalkOptions{Weight: "w"}); err != ErrNegativeWeight {
+		t.Errorf("Error negative weight: %v", err)
+	}
+}
+
+func TestNode2VecWalks(t *testing.T) {
+	// path 0-1-2-...-9 with a triangle at 1
+	g := NewUndirected()
+	for i := 0; i < 9; i++ {
+		g.Edge(string(rune('0'+i)), string(rune('1'+i)))
+	}
+	g.Edge("0", "2")
+
+	returns := func(p, q float64) int {
+		walks, err := RandomWalks(g, WalkOptions{Length: 10, Walks: 20, P: p, Q: q, Seed: 3})
+		if err != nil {
+			t.Fatalf("Error walking: %v", err)
+		}
+		if len(walks) != 200 {
+			t.Errorf("Error walk count (200): %d", len(walks))
+		}
+		n := 0
+		for _, w := range walks {
+			for i := 2; i < len(w); i++ {
+				if w[i] == w[i-2] {
+					n++
+				}
+			}
+		}
+		return n
+	}
+	if low, high := returns(100, 1), returns(0.01, 1); low*5 > high {
+		t.Errorf("Error p should control returns: %d %d", low, high)
+	}
+
+	var out bytes.Buffer
+	WriteWalks(&out, [][]string{{"a", "b"}, {"c"}})
+	if out.String() != "a b\nc\n" {
+		t.Errorf("Error writing walks: %q", out.String())
+	}
+}
+
+func TestPersonalizedPageRank(t *testing.T) {
+	g := NewDirected()
+	g.Edge("s", "a")
+	g.Edge("s", "b")
+	g.Edge("a", "c")
+	g.Edge("b", "c")
+	g.Edge("c", "s")
+	g.Edge("x", "s")
+
+	rank, err := PersonalizedPageRank(g, "s", WalkOptions{Walks: 20000, Seed: 1})
+	if err != nil {
+		t.Fatalf("Error ranking: %v", err)
+	}
+	total := 0.0
+	for _, r := range rank {
+		total += r
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("Error total rank (1): %f", total)
+	}
+	if rank["x"] != 0 || rank["s"] < rank["c"] || rank["c"] < rank["a"] {
+		t.Errorf("Error ranks: %v", rank)
+	}
+	if math.Abs(rank["a"]-rank["b"]) > 0.02 {
+		t.Errorf("Error symmetric ranks: %v", rank)
+	}
+	if _, err := PersonalizedPageRank(g, "y", WalkOptions{}); err != ErrVertexNotFound {
+		t.Errorf("Error missing source: %v", err)
+	}
+}
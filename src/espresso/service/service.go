@@ -0,0 +1,617 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"espresso/graph"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Request bodies larger than MaxBodyBytes are rejected.
+type Service struct {
+	sync.RWMutex
+	MaxBodyBytes int64
+	graphs       map[string]*graph.Graph
+}
+
+func New() *Service {
+	return &Service{MaxBodyBytes: 32 << 20, graphs: make(map[string]*graph.Graph)}
+}
+
+type httpError struct {
+	status  int
+	message string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+func errorf(status int, format string, args ...interface{}) error {
+	return &httpError{status, fmt.Sprintf(format, args...)}
+}
+
+var errMethod = errorf(http.StatusMethodNotAllowed, "method not allowed")
+
+type request struct {
+	*http.Request
+	path []string
+}
+
+func (r *request) decode(v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return bodyError(err)
+	}
+	return nil
+}
+
+func bodyError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return errorf(http.StatusRequestEntityTooLarge, "request body larger than %d bytes", tooLarge.Limit)
+	}
+	return errorf(http.StatusBadRequest, "invalid json body: %v", err)
+}
+
+func (this *Service) ServeHTTP(out http.ResponseWriter, req *http.Request) {
+	path := make([]string, 0)
+	for _, p := range strings.Split(strings.Trim(req.URL.EscapedPath(), "/"), "/") {
+		s, err := url.PathUnescape(p)
+		if err != nil {
+			write(out, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		path = append(path, s)
+	}
+	req.Body = http.MaxBytesReader(out, req.Body, this.MaxBodyBytes)
+
+	status, body, err := this.route(&request{req, path})
+	if err != nil {
+		status = http.StatusInternalServerError
+		if e, ok := err.(*httpError); ok {
+			status = e.status
+		}
+		body = map[string]string{"error": err.Error()}
+	}
+	write(out, status, body)
+}
+
+func write(out http.ResponseWriter, status int, body interface{}) {
+	if body == nil {
+		out.WriteHeader(status)
+		return
+	}
+	b, ok := body.(json.RawMessage)
+	if !ok {
+		var err error
+		if b, err = json.Marshal(body); err != nil {
+			status, b = http.StatusInternalServerError, nil
+		}
+	}
+	out.Header().Set("Content-Type", "application/json")
+	out.WriteHeader(status)
+	out.Write(append(b, '\n'))
+}
+
+// Bodies referencing the graph are marshaled while the lock is still held.
+func encode(status int, body interface{}, err error) (int, interface{}, error) {
+	if err != nil || body == nil {
+		return status, body, err
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return status, json.RawMessage(b), nil
+}
+
+func (this *Service) route(r *request) (int, interface{}, error) {
+	if len(r.path) == 0 || r.path[0] != "graphs" {
+		return 0, nil, errorf(http.StatusNotFound, "not found")
+	}
+	if len(r.path) == 1 {
+		if r.Method != http.MethodGet {
+			return 0, nil, errMethod
+		}
+		return this.listGraphs()
+	}
+
+	name := r.path[1]
+	if len(r.path) == 2 {
+		switch r.Method {
+		case http.MethodGet:
+			return this.read(name, func(g *graph.Graph) (int, interface{}, error) {
+				return http.StatusOK, g, nil
+			})
+		case http.MethodPut, http.MethodPost:
+			return this.createGraph(name, r)
+		case http.MethodDelete:
+			return this.deleteGraph(name)
+		}
+		return 0, nil, errMethod
+	}
+
+	rest := r.path[2:]
+	switch rest[0] {
+	case "data":
+		if len(rest) != 2 {
+			break
+		}
+		body, err := decodeData(r)
+		if err != nil {
+			return 0, nil, err
+		}
+		return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+			return body.apply(func(k string, value interface{}) { g.Set(k, value) }, g.Unset, rest[1])
+		})
+	case "vertices":
+		return this.vertices(name, r, rest[1:])
+	case "edges":
+		return this.edges(name, r, rest[1:])
+	case "traverse":
+		if len(rest) == 1 && r.Method == http.MethodGet {
+			return this.read(name, func(g *graph.Graph) (int, interface{}, error) {
+				return traverse(g, r.URL.Query())
+			})
+		}
+	case "paths":
+		if len(rest) == 1 && r.Method == http.MethodGet {
+			return this.read(name, func(g *graph.Graph) (int, interface{}, error) {
+				return paths(g, r.URL.Query())
+			})
+		}
+	}
+	return 0, nil, errorf(http.StatusNotFound, "not found")
+}
+
+func (this *Service) read(name string, f func(g *graph.Graph) (int, interface{}, error)) (int, interface{}, error) {
+	this.RLock()
+	defer this.RUnlock()
+	g, ok := this.graphs[name]
+	if !ok {
+		return 0, nil, errorf(http.StatusNotFound, "graph not found: %s", name)
+	}
+	return encode(f(g))
+}
+
+func (this *Service) write(name string, f func(g *graph.Graph) (int, interface{}, error)) (int, interface{}, error) {
+	this.Lock()
+	defer this.Unlock()
+	g, ok := this.graphs[name]
+	if !ok {
+		return 0, nil, errorf(http.StatusNotFound, "graph not found: %s", name)
+	}
+	return encode(f(g))
+}
+
+func (this *Service) listGraphs() (int, interface{}, error) {
+	this.RLock()
+	defer this.RUnlock()
+
+	type summary struct {
+		Name       string          `json:"name"`
+		Type       graph.GraphType `json:"type"`
+		Vertices   int             `json:"vertices"`
+		Edges      int             `json:"edges"`
+		HyperEdges int             `json:"hyperedges,omitempty"`
+	}
+	out := make([]summary, 0, len(this.graphs))
+	for name, g := range this.graphs {
+		out = append(out, summary{name, g.Type(), g.VertexCount(), g.EdgeCount(), g.HyperEdgeCount()})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return http.StatusOK, out, nil
+}
+
+// Body is optional and may carry a whole graph to import.
+func (this *Service) createGraph(name string, r *request) (int, interface{}, error) {
+	g := graph.New()
+	if err := json.NewDecoder(r.Body).Decode(g); err != nil && err != io.EOF {
+		return 0, nil, bodyError(err)
+	}
+
+	this.Lock()
+	defer this.Unlock()
+	if _, ok := this.graphs[name]; ok {
+		return 0, nil, errorf(http.StatusConflict, "graph already exists: %s", name)
+	}
+	this.graphs[name] = g
+	return encode(http.StatusCreated, g, nil)
+}
+
+func (this *Service) deleteGraph(name string) (int, interface{}, error) {
+	this.Lock()
+	defer this.Unlock()
+	if _, ok := this.graphs[name]; !ok {
+		return 0, nil, errorf(http.StatusNotFound, "graph not found: %s", name)
+	}
+	delete(this.graphs, name)
+	return http.StatusNoContent, nil, nil
+}
+
+// dataBody is decoded before the write lock is taken, a slow client must not hold it.
+type dataBody struct {
+	method string
+	value  interface{}
+}
+
+func decodeData(r *request) (*dataBody, error) {
+	body := &dataBody{method: r.Method}
+	switch r.Method {
+	case http.MethodPut:
+		if err := r.decode(&body.value); err != nil {
+			return nil, err
+		}
+	case http.MethodDelete:
+	default:
+		return nil, errMethod
+	}
+	return body, nil
+}
+
+func (this *dataBody) apply(set func(key string, value interface{}), unset func(key string), key string) (int, interface{}, error) {
+	if this.method == http.MethodPut {
+		set(key, this.value)
+	} else {
+		unset(key)
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+type vertexBody struct {
+	Id    string                 `json:"id"`
+	Label *string                `json:"label"`
+	Data  map[string]interface{} `json:"data"`
+}
+
+func (this *Service) vertices(name string, r *request, rest []string) (int, interface{}, error) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			return this.read(name, func(g *graph.Graph) (int, interface{}, error) {
+				return http.StatusOK, g.Vertices(), nil
+			})
+		case http.MethodPost:
+			var body vertexBody
+			if err := r.decode(&body); err != nil {
+				return 0, nil, err
+			}
+			if body.Id == "" {
+				return 0, nil, errorf(http.StatusBadRequest, "vertex id is required")
+			}
+			return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+				if g.HasVertex(body.Id) {
+					return 0, nil, errorf(http.StatusConflict, "vertex already exists: %s", body.Id)
+				}
+				v := g.Vertex(body.Id)
+				updateVertex(v, &body)
+				return http.StatusCreated, v, nil
+			})
+		}
+		return 0, nil, errMethod
+	}
+
+	id := rest[0]
+	vertex := func(g *graph.Graph) (*graph.Vertex, error) {
+		if !g.HasVertex(id) {
+			return nil, errorf(http.StatusNotFound, "vertex not found: %s", id)
+		}
+		return g.Vertex(id), nil
+	}
+
+	if len(rest) == 3 && rest[1] == "data" {
+		body, err := decodeData(r)
+		if err != nil {
+			return 0, nil, err
+		}
+		return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+			v, err := vertex(g)
+			if err != nil {
+				return 0, nil, err
+			}
+			return body.apply(func(k string, value interface{}) { v.Set(k, value) }, v.Unset, rest[2])
+		})
+	}
+	if len(rest) != 1 {
+		return 0, nil, errorf(http.StatusNotFound, "not found")
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return this.read(name, func(g *graph.Graph) (int, interface{}, error) {
+			v, err := vertex(g)
+			return http.StatusOK, v, err
+		})
+	case http.MethodPut:
+		var body vertexBody
+		if err := r.decode(&body); err != nil {
+			return 0, nil, err
+		}
+		return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+			v, err := vertex(g)
+			if err != nil {
+				return 0, nil, err
+			}
+			updateVertex(v, &body)
+			return http.StatusOK, v, nil
+		})
+	case http.MethodDelete:
+		return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+			v, err := vertex(g)
+			if err != nil {
+				return 0, nil, err
+			}
+			v.Remove()
+			return http.StatusNoContent, nil, nil
+		})
+	}
+	return 0, nil, errMethod
+}
+
+func updateVertex(v *graph.Vertex, body *vertexBody) {
+	if body.Label != nil {
+		v.Label(*body.Label)
+	}
+	v.SetMap(body.Data)
+}
+
+type edgeBody struct {
+	Id       string                 `json:"id"`
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
+	Vertices []string               `json:"vertices"`
+	Directed *bool                  `json:"directed"`
+	Label    *string                `json:"label"`
+	Data     map[string]interface{} `json:"data"`
+}
+
+// Edges are addressed as edges/{from}/{to}/{n}, n counting parallel edges from 0.
+func (this *Service) edges(name string, r *request, rest []string) (int, interface{}, error) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			return this.read(name, func(g *graph.Graph) (int, interface{}, error) {
+				q := r.URL.Query()
+				if from, to := q.Get("from"), q.Get("to"); from != "" && to != "" {
+					edges := g.Edges(from, to)
+					if edges == nil {
+						edges = []*graph.Edge{}
+					}
+					return http.StatusOK, edges, nil
+				}
+				return http.StatusOK, append(g.AllEdges(), g.HyperEdges()...), nil
+			})
+		case http.MethodPost:
+			var body edgeBody
+			if err := r.decode(&body); err != nil {
+				return 0, nil, err
+			}
+			if body.Vertices == nil && (body.From == "" || body.To == "") {
+				return 0, nil, errorf(http.StatusBadRequest, "edge from and to are required")
+			}
+			for _, id := range body.Vertices {
+				if id == "" {
+					return 0, nil, errorf(http.StatusBadRequest, "edge vertex ids must not be empty")
+				}
+			}
+			if body.Id != "" && (body.Vertices != nil || body.Directed != nil) {
+				return 0, nil, errorf(http.StatusBadRequest, "edge id is only supported with from and to")
+			}
+			return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+				if body.Directed != nil && g.Type() != graph.MIXED && *body.Directed != (g.Type() == graph.DIRECTED) {
+					return 0, nil, errorf(http.StatusBadRequest, "edge direction does not match %s graph", g.Type())
+				}
+				var e *graph.Edge
+				switch {
+				case body.Id != "":
+					if g.EdgeById(body.Id) != nil {
+						return 0, nil, errorf(http.StatusConflict, "edge exists: %s", body.Id)
+					}
+					var err error
+					if e, err = g.EdgeWithId(body.Id, body.From, body.To); err != nil {
+						return 0, nil, errorf(http.StatusConflict, "%v: %s", err, body.Id)
+					}
+				case body.Vertices != nil:
+					var err error
+					if e, err = g.HyperEdge(body.Vertices...); err != nil {
+						return 0, nil, errorf(http.StatusBadRequest, "%v", err)
+					}
+				case body.Directed != nil && !*body.Directed && g.Type() == graph.MIXED:
+					e = g.UndirectedEdge(body.From, body.To)
+				default:
+					e = g.Edge(body.From, body.To)
+				}
+				updateEdge(e, &body)
+				return http.StatusCreated, e, nil
+			})
+		}
+		return 0, nil, errMethod
+	}
+
+	if len(rest) == 1 {
+		return this.edgeById(name, r, rest[0])
+	}
+	if len(rest) != 3 && !(len(rest) == 5 && rest[3] == "data") {
+		return 0, nil, errorf(http.StatusNotFound, "not found")
+	}
+	n, err := strconv.Atoi(rest[2])
+	if err != nil {
+		return 0, nil, errorf(http.StatusBadRequest, "invalid edge index: %s", rest[2])
+	}
+	edge := func(g *graph.Graph) (*graph.Edge, error) {
+		edges := g.Edges(rest[0], rest[1])
+		if n < 0 || n >= len(edges) {
+			return nil, errorf(http.StatusNotFound, "edge not found: %s/%s/%d", rest[0], rest[1], n)
+		}
+		return edges[n], nil
+	}
+
+	if len(rest) == 5 {
+		body, err := decodeData(r)
+		if err != nil {
+			return 0, nil, err
+		}
+		return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+			e, err := edge(g)
+			if err != nil {
+				return 0, nil, err
+			}
+			return body.apply(func(k string, value interface{}) { e.Set(k, value) }, e.Unset, rest[4])
+		})
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return this.read(name, func(g *graph.Graph) (int, interface{}, error) {
+			e, err := edge(g)
+			return http.StatusOK, e, err
+		})
+	case http.MethodPut:
+		var body edgeBody
+		if err := r.decode(&body); err != nil {
+			return 0, nil, err
+		}
+		return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+			e, err := edge(g)
+			if err != nil {
+				return 0, nil, err
+			}
+			updateEdge(e, &body)
+			return http.StatusOK, e, nil
+		})
+	case http.MethodDelete:
+		return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+			e, err := edge(g)
+			if err != nil {
+				return 0, nil, err
+			}
+			e.Remove()
+			return http.StatusNoContent, nil, nil
+		})
+	}
+	return 0, nil, errMethod
+}
+
+func (this *Service) edgeById(name string, r *request, id string) (int, interface{}, error) {
+	edge := func(g *graph.Graph) (*graph.Edge, error) {
+		if e := g.EdgeById(id); e != nil {
+			return e, nil
+		}
+		return nil, errorf(http.StatusNotFound, "edge not found: %s", id)
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return this.read(name, func(g *graph.Graph) (int, interface{}, error) {
+			e, err := edge(g)
+			return http.StatusOK, e, err
+		})
+	case http.MethodPut:
+		var body edgeBody
+		if err := r.decode(&body); err != nil {
+			return 0, nil, err
+		}
+		return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+			e, err := edge(g)
+			if err != nil {
+				return 0, nil, err
+			}
+			updateEdge(e, &body)
+			return http.StatusOK, e, nil
+		})
+	case http.MethodDelete:
+		return this.write(name, func(g *graph.Graph) (int, interface{}, error) {
+			e, err := edge(g)
+			if err != nil {
+				return 0, nil, err
+			}
+			e.Remove()
+			return http.StatusNoContent, nil, nil
+		})
+	}
+	return 0, nil, errMethod
+}
+
+func updateEdge(e *graph.Edge, body *edgeBody) {
+	if body.Label != nil {
+		e.Label(*body.Label)
+	}
+	e.SetMap(body.Data)
+}
+
+type visited struct {
+	Vertex *graph.Vertex `json:"vertex"`
+	Depth  int           `json:"depth"`
+}
+
+// traverse?from=ID&order=bfs|dfs&depth=N
+func traverse(g *graph.Graph, q url.Values) (int, interface{}, error) {
+	max := -1
+	if s := q.Get("depth"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return 0, nil, errorf(http.StatusBadRequest, "invalid depth: %s", s)
+		}
+		max = n
+	}
+	order := graph.BreadthFirstWithin
+	switch q.Get("order") {
+	case "", "bfs":
+	case "dfs":
+		order = graph.DepthFirstWithin
+	default:
+		return 0, nil, errorf(http.StatusBadRequest, "invalid order: %s", q.Get("order"))
+	}
+
+	out := make([]visited, 0)
+	err := order(g, q.Get("from"), max, func(v *graph.Vertex, depth int) bool {
+		out = append(out, visited{v, depth})
+		return true
+	})
+	if err != nil {
+		return 0, nil, errorf(http.StatusNotFound, "%v: %s", err, q.Get("from"))
+	}
+	return http.StatusOK, out, nil
+}
+
+type pathBody struct {
+	Weight   float64       `json:"weight"`
+	Vertices []string      `json:"vertices"`
+	Edges    []*graph.Edge `json:"edges"`
+	Path     string        `json:"path"`
+}
+
+// paths?from=ID&to=ID&weight=KEY&k=N
+func paths(g *graph.Graph, q url.Values) (int, interface{}, error) {
+	k := 1
+	if s := q.Get("k"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return 0, nil, errorf(http.StatusBadRequest, "invalid k: %s", s)
+		}
+		k = n
+	}
+	found, err := graph.KShortestPaths(g, q.Get("from"), q.Get("to"), k, q.Get("weight"))
+	switch {
+	case err == graph.ErrVertexNotFound:
+		return 0, nil, errorf(http.StatusNotFound, "%v", err)
+	case err != nil:
+		return 0, nil, errorf(http.StatusBadRequest, "%v", err)
+	}
+
+	out := make([]pathBody, len(found))
+	for i, p := range found {
+		ids := make([]string, len(p.Vertices))
+		for j, v := range p.Vertices {
+			ids[j] = v.Id()
+		}
+		out[i] = pathBody{p.Weight, ids, p.Edges, p.String()}
+	}
+	return http.StatusOK, out, nil
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type client struct {
+	t      *testing.T
+	server *httptest.Server
+}
+
+func (c *client) do(method, path, body string, status int) interface{} {
+	req, err := http.NewRequest(method, c.server.URL+path, strings.NewReader(body))
+	if err != nil {
+		c.t.Fatalf("Error creating request %s %s: %v", method, path, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatalf("Error request %s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	var out interface{}
+	json.NewDecoder(resp.Body).Decode(&out)
+	if resp.StatusCode != status {
+		c.t.Errorf("Error %s %s status (%d): %d %v", method, path, status, resp.StatusCode, out)
+	}
+	return out
+}
+
+func TestService(t *testing.T) {
+	server := httptest.NewServer(New())
+	defer server.Close()
+	c := &client{t, server}
+
+	c.do("PUT", "/graphs/movies", `{"type":"DIRECTED","data":{"name":"Neo4j tutorial"}}`, http.StatusCreated)
+	c.do("PUT", "/graphs/movies", "", http.StatusConflict)
+	c.do("PUT", "/graphs/empty", "", http.StatusCreated)
+
+	if list := c.do("GET", "/graphs", "", http.StatusOK).([]interface{}); len(list) != 2 {
+		t.Errorf("Error listing graphs (2): %v", list)
+	}
+
+	c.do("POST", "/graphs/movies/vertices", `{"id":"0","label":"Movie","data":{"title":"The Matrix"}}`, http.StatusCreated)
+	c.do("POST", "/graphs/movies/vertices", `{"id":"0"}`, http.StatusConflict)
+	c.do("POST", "/graphs/movies/vertices", `{"id":"3","label":"Actor"}`, http.StatusCreated)
+	c.do("PUT", "/graphs/movies/vertices/3/data/name", `"Keanu Reeves"`, http.StatusNoContent)
+	c.do("POST", "/graphs/movies/edges", `{"from":"3","to":"0","label":"ACTS_IN","data":{"role":"Neo"}}`, http.StatusCreated)
+	c.do("POST", "/graphs/movies/edges", `{"from":"3","to":"1","label":"ACTS_IN"}`, http.StatusCreated)
+	c.do("PUT", "/graphs/movies/edges/3/1/0/data/role", `"Neo"`, http.StatusNoContent)
+
+	v := c.do("GET", "/graphs/movies/vertices/3", "", http.StatusOK).(map[string]interface{})
+	if data := v["data"].(map[string]interface{}); data["name"] != "Keanu Reeves" {
+		t.Errorf("Error vertex data: %v", v)
+	}
+	e := c.do("GET", "/graphs/movies/edges/3/1/0", "", http.StatusOK).(map[string]interface{})
+	if e["label"] != "ACTS_IN" || e["data"].(map[string]interface{})["role"] != "Neo" {
+		t.Errorf("Error edge: %v", e)
+	}
+
+	visits := c.do("GET", "/graphs/movies/traverse?from=3", "", http.StatusOK).([]interface{})
+	if len(visits) != 3 {
+		t.Errorf("Error traversal (3): %v", visits)
+	}
+	found := c.do("GET", "/graphs/movies/paths?from=3&to=0", "", http.StatusOK).([]interface{})
+	if len(found) != 1 || found[0].(map[string]interface{})["weight"] != 1.0 {
+		t.Errorf("Error paths: %v", found)
+	}
+	c.do("GET", "/graphs/movies/paths?from=3&to=9", "", http.StatusNotFound)
+
+	c.do("DELETE", "/graphs/movies/vertices/3/data/name", "", http.StatusNoContent)
+	c.do("DELETE", "/graphs/movies/edges/3/0/0", "", http.StatusNoContent)
+	c.do("DELETE", "/graphs/movies/edges/3/0/0", "", http.StatusNotFound)
+	c.do("DELETE", "/graphs/movies/vertices/1", "", http.StatusNoContent)
+	c.do("GET", "/graphs/movies/vertices/1", "", http.StatusNotFound)
+
+	g := c.do("GET", "/graphs/movies", "", http.StatusOK).(map[string]interface{})
+	if len(g["vertices"].([]interface{})) != 2 || len(g["edges"].([]interface{})) != 0 {
+		t.Errorf("Error graph after deletes: %v", g)
+	}
+
+	c.do("POST", "/graphs/movies/vertices", `{bad json`, http.StatusBadRequest)
+	c.do("PATCH", "/graphs/movies", "", http.StatusMethodNotAllowed)
+	c.do("GET", "/nothing", "", http.StatusNotFound)
+	c.do("DELETE", "/graphs/movies", "", http.StatusNoContent)
+	c.do("GET", "/graphs/movies", "", http.StatusNotFound)
+}
+
+func TestServiceMixedEdges(t *testing.T) {
+	server := httptest.NewServer(New())
+	defer server.Close()
+	c := &client{t, server}
+
+	c.do("PUT", "/graphs/team", `{"type":"MIXED"}`, http.StatusCreated)
+	c.do("POST", "/graphs/team/edges", `{"from":"a","to":"b"}`, http.StatusCreated)
+	c.do("POST", "/graphs/team/edges", `{"from":"b","to":"c","directed":false}`, http.StatusCreated)
+	c.do("POST", "/graphs/team/edges", `{"vertices":["a","b","c"],"label":"paper"}`, http.StatusCreated)
+	c.do("POST", "/graphs/team/edges", `{"vertices":[]}`, http.StatusBadRequest)
+	c.do("POST", "/graphs/team/edges", `{"vertices":["a",""]}`, http.StatusBadRequest)
+	c.do("PUT", "/graphs/plain", `{"type":"DIRECTED"}`, http.StatusCreated)
+	c.do("POST", "/graphs/plain/edges", `{"from":"a","to":"b","directed":false}`, http.StatusBadRequest)
+	c.do("POST", "/graphs/plain/edges", `{"from":"a","to":""}`, http.StatusBadRequest)
+	c.do("POST", "/graphs/team/edges", `{"vertices":["a","a"]}`, http.StatusBadRequest)
+
+	if edges := c.do("GET", "/graphs/team/edges?from=c&to=b", "", http.StatusOK).([]interface{}); len(edges) != 2 {
+		t.Errorf("Error undirected and hyper edges from c to b (2): %v", edges)
+	}
+	edges := c.do("GET", "/graphs/team/edges", "", http.StatusOK).([]interface{})
+	if len(edges) != 3 || edges[2].(map[string]interface{})["label"] != "paper" {
+		t.Errorf("Error listing mixed edges: %v", edges)
+	}
+	c.do("DELETE", "/graphs/team/edges/a/c/0", "", http.StatusNoContent)
+	if edges := c.do("GET", "/graphs/team/edges", "", http.StatusOK).([]interface{}); len(edges) != 2 {
+		t.Errorf("Error deleting hyperedge: %v", edges)
+	}
+}
+
+func TestServiceEdgeIds(t *testing.T) {
+	server := httptest.NewServer(New())
+	defer server.Close()
+	c := &client{t, server}
+
+	c.do("PUT", "/graphs/g", "", http.StatusCreated)
+	e := c.do("POST", "/graphs/g/edges", `{"from":"2","to":"3"}`, http.StatusCreated).(map[string]interface{})
+	c.do("POST", "/graphs/g/edges", `{"id":"second","from":"2","to":"3","data":{"n":2}}`, http.StatusCreated)
+	c.do("POST", "/graphs/g/edges", `{"id":"second","from":"3","to":"2"}`, http.StatusConflict)
+	c.do("POST", "/graphs/g/edges", `{"id":"h","vertices":["1","2"]}`, http.StatusBadRequest)
+
+	if e["id"] != "e1" {
+		t.Errorf("Error generated edge id (e1): %v", e)
+	}
+	second := c.do("GET", "/graphs/g/edges/second", "", http.StatusOK).(map[string]interface{})
+	if second["data"].(map[string]interface{})["n"] != 2.0 {
+		t.Errorf("Error edge by id: %v", second)
+	}
+	c.do("PUT", "/graphs/g/edges/second", `{"label":"AGAIN"}`, http.StatusOK)
+	if e := c.do("GET", "/graphs/g/edges/2/3/1", "", http.StatusOK).(map[string]interface{}); e["label"] != "AGAIN" || e["id"] != "second" {
+		t.Errorf("Error updating edge by id: %v", e)
+	}
+	c.do("DELETE", "/graphs/g/edges/e1", "", http.StatusNoContent)
+	c.do("GET", "/graphs/g/edges/e1", "", http.StatusNotFound)
+	if edges := c.do("GET", "/graphs/g/edges?from=2&to=3", "", http.StatusOK).([]interface{}); len(edges) != 1 {
+		t.Errorf("Error edges after delete by id (1): %v", edges)
+	}
+	c.do("POST", "/graphs/g/edges", `{"id":"e1","from":"2","to":"3"}`, http.StatusConflict)
+	if e := c.do("POST", "/graphs/g/edges", `{"from":"2","to":"3"}`, http.StatusCreated).(map[string]interface{}); e["id"] != "e2" {
+		t.Errorf("Error id after delete (e2): %v", e)
+	}
+}
+
+func TestServiceConcurrent(t *testing.T) {
+	s := New()
+	do := func(method, path, body string, status int) {
+		out := httptest.NewRecorder()
+		s.ServeHTTP(out, httptest.NewRequest(method, path, strings.NewReader(body)))
+		if out.Code != status {
+			t.Errorf("Error %s %s status (%d): %d %s", method, path, status, out.Code, out.Body)
+		}
+	}
+
+	do("PUT", "/graphs/g", "", http.StatusCreated)
+	do("POST", "/graphs/g/vertices", `{"id":"a"}`, http.StatusCreated)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				id := strconv.Itoa(i*100 + j)
+				do("GET", "/graphs/g", "", http.StatusOK)
+				do("POST", "/graphs/g/vertices", `{"id":"`+id+`","data":{"n":1}}`, http.StatusCreated)
+				do("PUT", "/graphs/g/data/k"+id, `"v"`, http.StatusNoContent)
+				do("PUT", "/graphs/g/vertices/a/data/k", id, http.StatusNoContent)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if n := s.graphs["g"].VertexCount(); n != 81 {
+		t.Errorf("Error concurrent vertices (81): %d", n)
+	}
+}
+
+func TestServiceLimits(t *testing.T) {
+	s := New()
+	s.MaxBodyBytes = 64
+	server := httptest.NewServer(s)
+	defer server.Close()
+	c := &client{t, server}
+
+	c.do("PUT", "/graphs/g", `{"data":{"text":"`+strings.Repeat("x", 100)+`"}}`, http.StatusRequestEntityTooLarge)
+	c.do("PUT", "/graphs/g", "", http.StatusCreated)
+	c.do("PUT", "/graphs/g/data/text", `"`+strings.Repeat("x", 100)+`"`, http.StatusRequestEntityTooLarge)
+	for _, e := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}} {
+		c.do("POST", "/graphs/g/edges", `{"from":"`+e[0]+`","to":"`+e[1]+`"}`, http.StatusCreated)
+	}
+	for _, order := range []string{"bfs", "dfs"} {
+		visits := c.do("GET", "/graphs/g/traverse?from=a&depth=2&order="+order, "", http.StatusOK).([]interface{})
+		if len(visits) != 3 {
+			t.Errorf("Error %s traversal within depth 2 (3): %v", order, visits)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"espresso/service"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -35,10 +36,13 @@ func LoadConfiguration(file string) *Configuration {
 
 var config = LoadConfiguration("conf/config.json")
 
-type HttpHandler struct{}
+type HttpHandler struct {
+	graphs *service.Service
+}
 
 func (this HttpHandler) ServeHTTP(out http.ResponseWriter, req *http.Request) {
-	fmt.Fprintln(out, "May I help you?")
+	log.Println(req.Method, req.URL)
+	this.graphs.ServeHTTP(out, req)
 }
 
 func main() {
@@ -52,7 +56,7 @@ func main() {
 	fmt.Println(config)
 
 	fmt.Println("HTTP Server start!")
-	err := http.ListenAndServe(config.HttpBindAddress, &HttpHandler{})
+	err := http.ListenAndServe(config.HttpBindAddress, &HttpHandler{service.New()})
 	if err != nil {
 		log.Println("Error starting HTTP Server", config.HttpBindAddress, err)
 	}
@@ -2,10 +2,19 @@ package main
 
 import (
 	"espresso/graph"
+	"flag"
 	"fmt"
 )
 
 func main() {
+
+	flag.Parse()
+
+	if *optStats != "" {
+		printStats(*optStats)
+		return
+	}
+
 	{
 		fmt.Println("Empty Graph")
 
@@ -78,6 +87,10 @@ func main() {
 		g.Edge("2", "3")
 		g.Edge("2", "2")
 
+		for _, e := range g.Edges("2", "3") {
+			fmt.Println("edge", e.Id(), "from 2 to 3")
+		}
+
 		fmt.Println("all")
 		fmt.Print(g)
 
@@ -140,6 +153,21 @@ func main() {
 		e52.Set("role", "Trinity")
 
 		fmt.Println(g)
+
+		for _, v := range []*graph.Vertex{v0, v1, v2} {
+			title, _ := v.GetString("title")
+			year, err := v.GetTime("year")
+			if err != nil {
+				fmt.Println("Error:", err)
+				continue
+			}
+			fmt.Printf("%s (%d)\n", title, year.Year())
+		}
+
+		for _, hit := range g.TextIndex().Search("matrix reloaded") {
+			title, _ := hit.Vertex.GetString("title")
+			fmt.Printf("Search \"matrix reloaded\": %s %s (%.2f)\n", hit.Vertex.GetLabel(), title, hit.Score)
+		}
 	}
 
 }
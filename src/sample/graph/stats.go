@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"espresso/graph"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	optStats      = flag.String("stats", "", "Print statistics of a graph file instead of the samples")
+	optFormat     = flag.String("format", "", "Graph file format: json, csv, edgelist or adjlist (default by extension)")
+	optNodes      = flag.String("nodes", "", "Comma separated node CSV files loaded before a csv relationships file")
+	optUndirected = flag.Bool("undirected", false, "Load the graph file as undirected")
+)
+
+func format(path string) string {
+	if *optFormat != "" {
+		return *optFormat
+	}
+	switch filepath.Ext(path) {
+	case ".json":
+		return "json"
+	case ".csv":
+		return "csv"
+	case ".adj", ".adjlist":
+		return "adjlist"
+	}
+	return "edgelist"
+}
+
+func load(path string) (*graph.Graph, error) {
+	g := graph.NewDirected()
+	if *optUndirected {
+		g = graph.NewUndirected()
+	}
+	kind := format(path)
+	if kind == "json" {
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return g, json.Unmarshal(raw, g)
+	}
+
+	im := graph.NewImporter(g)
+	read := func(path string, f func(*os.File) error) error {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if err := f(file); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		return nil
+	}
+	switch kind {
+	case "csv":
+		if *optNodes != "" {
+			for _, nodes := range strings.Split(*optNodes, ",") {
+				if err := read(nodes, func(f *os.File) error { return im.Nodes(f) }); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return g, read(path, func(f *os.File) error { return im.Relationships(f) })
+	case "edgelist":
+		return g, read(path, func(f *os.File) error { return im.EdgeList(f) })
+	case "adjlist":
+		return g, read(path, func(f *os.File) error { return im.AdjacencyList(f) })
+	}
+	return nil, fmt.Errorf("unknown format: %s", kind)
+}
+
+func printStats(path string) {
+	g, err := load(path)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	fmt.Print(graph.Stats(g))
+}